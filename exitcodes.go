@@ -0,0 +1,162 @@
+//
+// Copyright 2020 Cody Collier <cody@telnet.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/codycollier/tfs-model-status-probe/probe"
+)
+
+// exitCodeMap maps probe outcomes or categories to process exit codes. An
+// outcome mapping takes precedence over its category, and exit codes which
+// are not mapped at all are kept.
+type exitCodeMap map[string]int
+
+// Nagios plugin exit codes
+const (
+	nagiosOK       = 0
+	nagiosWarning  = 1
+	nagiosCritical = 2
+	nagiosUnknown  = 3
+)
+
+// The built-in profiles. The default profile keeps the probe exit codes.
+var exitCodeProfiles = map[string]exitCodeMap{
+	"default": {},
+	"nagios": {
+		"available":        nagiosOK,
+		"config":           nagiosUnknown,
+		"connection":       nagiosUnknown,
+		"rpc":              nagiosUnknown,
+		"not_found":        nagiosCritical,
+		"response":         nagiosCritical,
+		"timeout":          nagiosCritical,
+		"not_ready":        nagiosWarning,
+		"state_unknown":    nagiosCritical,
+		"state_end":        nagiosCritical,
+		"unexpected_state": nagiosUnknown,
+		"inference":        nagiosCritical,
+		"signature":        nagiosCritical,
+		"load_failed":      nagiosCritical,
+		"fleet":            nagiosCritical,
+		"constraint":       nagiosCritical,
+	},
+}
+
+// Map a probe exit code to the process exit code
+func (m exitCodeMap) exitCode(code int) int {
+	if mapped, ok := m[probe.OutcomeOf(code)]; ok {
+		return mapped
+	}
+	if mapped, ok := m[string(probe.CategoryOf(code))]; ok {
+		return mapped
+	}
+	return code
+}
+
+// Set a mapping, checking the outcome or category name and the exit code
+func (m exitCodeMap) set(name string, code int) error {
+	if !isOutcomeName(name) {
+		return fmt.Errorf("unknown outcome or category: %v", name)
+	}
+	if code < 0 || code > 255 {
+		return fmt.Errorf("exit code for %v out of range: %v", name, code)
+	}
+	m[name] = code
+	return nil
+}
+
+// Report whether a name is an outcome or a failure category
+func isOutcomeName(name string) bool {
+	for _, outcome := range probe.Outcomes() {
+		if name == outcome {
+			return true
+		}
+	}
+	switch probe.Category(name) {
+	case probe.CategoryConfig, probe.CategoryConnection, probe.CategoryRPC, probe.CategoryNotFound,
+		probe.CategoryResponse, probe.CategoryTimeout, probe.CategoryNotReady, probe.CategoryInference,
+		probe.CategorySignature, probe.CategoryLoadFailed, probe.CategoryFleet,
+		probe.CategoryConstraint:
+		return true
+	}
+	return false
+}
+
+// Build the exit code mapping from a profile, then a yaml or json file of
+// name: code mappings, then "name=code,..." overrides, each optional
+func newExitCodeMap(profile, path, overrides string) (exitCodeMap, error) {
+	base, ok := exitCodeProfiles[profile]
+	if !ok {
+		return nil, fmt.Errorf("unknown exit code profile: %v", profile)
+	}
+	m := exitCodeMap{}
+	for name, code := range base {
+		m[name] = code
+	}
+
+	if path != "" {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading exit code file: %v", err)
+		}
+		var codes map[string]int
+		if err := yaml.NewDecoder(bytes.NewReader(data)).Decode(&codes); err != nil {
+			return nil, fmt.Errorf("reading exit code file: %v", err)
+		}
+		for _, name := range sortedNames(codes) {
+			if err := m.set(name, codes[name]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if overrides != "" {
+		for _, pair := range strings.Split(overrides, ",") {
+			i := strings.Index(pair, "=")
+			if i < 0 {
+				return nil, fmt.Errorf("invalid exit code mapping: %q", pair)
+			}
+			code, err := strconv.Atoi(strings.TrimSpace(pair[i+1:]))
+			if err != nil {
+				return nil, fmt.Errorf("invalid exit code mapping: %q", pair)
+			}
+			if err := m.set(strings.TrimSpace(pair[:i]), code); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return m, nil
+}
+
+// The keys of a mapping in order, for stable error reporting
+func sortedNames(codes map[string]int) []string {
+	names := make([]string, 0, len(codes))
+	for name := range codes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,64 @@
+//
+// Copyright 2020 Cody Collier <cody@telnet.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExitCodeMap(t *testing.T) {
+	m, err := newExitCodeMap("default", "", "")
+	assert.Nil(t, err)
+	for _, code := range []int{0, 2, 10, 32, 65, 100} {
+		assert.Equal(t, code, m.exitCode(code))
+	}
+
+	m, err = newExitCodeMap("nagios", "", "")
+	assert.Nil(t, err)
+	expected := map[int]int{0: 0, 1: 3, 2: 3, 3: 3, 5: 3, 10: 2, 12: 2, 14: 2, 30: 2, 31: 1, 32: 1, 33: 1, 34: 2, 43: 2, 50: 2, 65: 2, 100: 3}
+	for code, mapped := range expected {
+		assert.Equal(t, mapped, m.exitCode(code), code)
+	}
+
+	// a file and overrides apply over the profile, outcomes over categories
+	path := filepath.Join(t.TempDir(), "exit-codes.yaml")
+	assert.Nil(t, ioutil.WriteFile(path, []byte("connection: 69\nstate_unloading: 0\n"), 0644))
+	m, err = newExitCodeMap("default", path, "dial=70, load_failed=60")
+	assert.Nil(t, err)
+	assert.Equal(t, 70, m.exitCode(2))
+	assert.Equal(t, 69, m.exitCode(4))
+	assert.Equal(t, 0, m.exitCode(33))
+	assert.Equal(t, 60, m.exitCode(65))
+	assert.Equal(t, 32, m.exitCode(32))
+
+	invalid := [][]string{
+		{"icinga", "", ""},
+		{"default", filepath.Join(t.TempDir(), "missing.yaml"), ""},
+		{"default", "", "dial"},
+		{"default", "", "dial=x"},
+		{"default", "", "dialing=2"},
+		{"default", "", "dial=256"},
+	}
+	for _, args := range invalid {
+		_, err := newExitCodeMap(args[0], args[1], args[2])
+		assert.NotNil(t, err, args)
+	}
+}
@@ -0,0 +1,228 @@
+//
+// Copyright 2020 Cody Collier <cody@telnet.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/codycollier/tfs-model-status-probe/probe"
+	"github.com/codycollier/tfs-model-status-probe/tfproto/tfproto"
+)
+
+var (
+	versionStateDesc = prometheus.NewDesc(
+		"tfs_model_version_state",
+		"Whether a model version is in the state (1) or not (0), as reported by model status.",
+		[]string{"model", "version", "state"}, nil,
+	)
+	modelAvailableDesc = prometheus.NewDesc(
+		"tfs_model_available",
+		"Whether the model passes the probe check (1) or not (0).",
+		[]string{"model"}, nil,
+	)
+	modelExitCodeDesc = prometheus.NewDesc(
+		"tfs_model_exit_code",
+		"The exit code of the probe for the model, 0 when AVAILABLE.",
+		[]string{"model"}, nil,
+	)
+)
+
+// The servable states, in the order of the enum
+var servableStates = []tfproto.ModelVersionStatus_State{
+	tfproto.ModelVersionStatus_UNKNOWN,
+	tfproto.ModelVersionStatus_START,
+	tfproto.ModelVersionStatus_LOADING,
+	tfproto.ModelVersionStatus_AVAILABLE,
+	tfproto.ModelVersionStatus_UNLOADING,
+	tfproto.ModelVersionStatus_END,
+}
+
+// exporter polls model status and exposes the results as prometheus
+// metrics. The state gauges follow the last poll, so versions which are no
+// longer reported drop out.
+type exporter struct {
+	prober  *probe.Prober
+	mu      sync.Mutex
+	results []probe.ModelResult
+	errors  *prometheus.CounterVec
+	latency *prometheus.HistogramVec
+}
+
+func newExporter(prober *probe.Prober) *exporter {
+	return &exporter{
+		prober: prober,
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "tfs_probe_errors_total",
+			Help: "Failed model status calls, by grpc code.",
+		}, []string{"model", "code"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "tfs_model_status_duration_seconds",
+			Help:    "Latency of model status calls.",
+			Buckets: prometheus.ExponentialBuckets(0.001, 2, 14),
+		}, []string{"model"}),
+	}
+}
+
+func (e *exporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- versionStateDesc
+	ch <- modelAvailableDesc
+	ch <- modelExitCodeDesc
+	e.errors.Describe(ch)
+	e.latency.Describe(ch)
+}
+
+func (e *exporter) Collect(ch chan<- prometheus.Metric) {
+	e.mu.Lock()
+	results := e.results
+	e.mu.Unlock()
+
+	collectModelResults(ch, results)
+	e.errors.Collect(ch)
+	e.latency.Collect(ch)
+}
+
+// Send the version state, availability and exit code metrics of each model
+func collectModelResults(ch chan<- prometheus.Metric, results []probe.ModelResult) {
+	for _, result := range results {
+		model := result.Model.String()
+		for _, res := range result.Response.GetModelVersionStatus() {
+			version := strconv.FormatInt(res.Version, 10)
+			for _, state := range servableStates {
+				value := 0.0
+				if res.State == state {
+					value = 1
+				}
+				ch <- prometheus.MustNewConstMetric(versionStateDesc, prometheus.GaugeValue, value, model, version, state.String())
+			}
+		}
+		available := 0.0
+		if result.ExitCode == probe.ExitAvailable {
+			available = 1
+		}
+		ch <- prometheus.MustNewConstMetric(modelAvailableDesc, prometheus.GaugeValue, available, model)
+		ch <- prometheus.MustNewConstMetric(modelExitCodeDesc, prometheus.GaugeValue, float64(result.ExitCode), model)
+	}
+}
+
+// Check the configured models and record the results. A failure to connect
+// is counted against every model.
+func (e *exporter) poll(ctx context.Context) {
+	models := e.prober.Config().Models
+	result := e.prober.CheckModels(ctx, models)
+
+	results := result.Models
+	dialFailed := results == nil
+	if dialFailed {
+		results = make([]probe.ModelResult, len(models))
+		for i, model := range models {
+			results[i] = probe.ModelResult{Model: model, Err: result.Err, ExitCode: result.ExitCode}
+		}
+	}
+	for _, r := range results {
+		if r.Err != nil {
+			code := status.Code(r.Err)
+			if dialFailed {
+				code = codes.Unavailable
+			}
+			e.errors.WithLabelValues(r.Model.String(), code.String()).Inc()
+		} else if r.Response != nil {
+			e.latency.WithLabelValues(r.Model.String()).Observe(r.Latency.Seconds())
+		}
+	}
+
+	e.mu.Lock()
+	e.results = results
+	e.mu.Unlock()
+}
+
+// Poll at the interval until the context is done
+func (e *exporter) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		e.poll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Run the exporter subcommand until interrupted, returning the exit code
+func runExporter(args []string) int {
+	fs := subcommandFlags("exporter")
+	listen := fs.String("listen", ":9108", "The host:port to serve /metrics on")
+	interval := fs.Duration("check-interval", time.Second*15, "Interval between model status checks")
+	fs.Parse(args)
+
+	config, err := configFromFlags()
+	if err == nil && config.Wait {
+		err = errors.New("the -wait option is not supported by exporter")
+	}
+	if err == nil && *interval <= 0 {
+		err = errors.New("the -check-interval must be positive")
+	}
+	var p *probe.Prober
+	if err == nil {
+		config.Logger = nil
+		p, err = probe.New(config)
+	}
+	if err != nil {
+		log.Printf("Error: %v\n", err)
+		return probe.ExitInvalidConfig
+	}
+	defer p.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	e := newExporter(p)
+	go e.run(ctx, *interval)
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(e)
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	server := &http.Server{Addr: *listen, Handler: mux}
+	go func() {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+		<-signals
+		server.Shutdown(context.Background())
+	}()
+
+	log.Printf("Serving metrics for %v on %v\n", p.Config().Addr, *listen)
+	if err := server.ListenAndServe(); err != http.ErrServerClosed {
+		log.Printf("Error serving: %v\n", err)
+		return probe.ExitInvalidConfig
+	}
+	return probe.ExitAvailable
+}
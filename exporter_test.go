@@ -0,0 +1,100 @@
+//
+// Copyright 2020 Cody Collier <cody@telnet.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/codycollier/tfs-model-status-probe/probe"
+	"github.com/codycollier/tfs-model-status-probe/tfproto/tfproto"
+	"github.com/codycollier/tfs-model-status-probe/tfstest"
+)
+
+func TestExporter(t *testing.T) {
+	server := tfstest.NewServer(tfstest.Model{Name: "half_plus_two", Versions: []tfstest.Version{
+		{Version: 2, Timeline: []tfstest.Step{{State: tfproto.ModelVersionStatus_LOADING}}},
+		{Version: 1, Timeline: tfstest.Available()},
+	}})
+	defer server.Close()
+
+	p, err := probe.New(probe.Config{Addr: server.Addr, Models: []probe.Model{{Name: "half_plus_two"}, {Name: "missing"}}})
+	assert.Nil(t, err)
+	defer p.Close()
+	e := newExporter(p)
+	e.poll(context.Background())
+	e.poll(context.Background())
+
+	expected := `
+# HELP tfs_model_available Whether the model passes the probe check (1) or not (0).
+# TYPE tfs_model_available gauge
+tfs_model_available{model="half_plus_two"} 1
+tfs_model_available{model="missing"} 0
+# HELP tfs_probe_errors_total Failed model status calls, by grpc code.
+# TYPE tfs_probe_errors_total counter
+tfs_probe_errors_total{code="NotFound",model="missing"} 2
+`
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(e)
+	assert.Nil(t, testutil.GatherAndCompare(registry, strings.NewReader(expected), "tfs_model_available", "tfs_probe_errors_total"))
+
+	metrics, err := registry.Gather()
+	assert.Nil(t, err)
+	values := map[string]float64{}
+	for _, family := range metrics {
+		for _, metric := range family.Metric {
+			labels := []string{}
+			for _, label := range metric.Label {
+				labels = append(labels, label.GetValue())
+			}
+			key := family.GetName() + "{" + strings.Join(labels, ",") + "}"
+			switch {
+			case metric.Gauge != nil:
+				values[key] = metric.Gauge.GetValue()
+			case metric.Histogram != nil:
+				values[key] = float64(metric.Histogram.GetSampleCount())
+			}
+		}
+	}
+	assert.Equal(t, 1.0, values["tfs_model_version_state{half_plus_two,LOADING,2}"])
+	assert.Equal(t, 0.0, values["tfs_model_version_state{half_plus_two,AVAILABLE,2}"])
+	assert.Equal(t, 1.0, values["tfs_model_version_state{half_plus_two,AVAILABLE,1}"])
+	assert.Equal(t, 0.0, values["tfs_model_exit_code{half_plus_two}"])
+	assert.Equal(t, 10.0, values["tfs_model_exit_code{missing}"])
+	assert.Equal(t, 2.0, values["tfs_model_status_duration_seconds{half_plus_two}"])
+}
+
+func TestExporterDialFailure(t *testing.T) {
+	server := tfstest.NewServer()
+	server.Close()
+
+	p, err := probe.New(probe.Config{Addr: server.Addr, ConnectTimeout: 100 * time.Millisecond, Models: []probe.Model{{Name: "half_plus_two"}}})
+	assert.Nil(t, err)
+	e := newExporter(p)
+	e.poll(context.Background())
+	assert.Equal(t, 1.0, testutil.ToFloat64(e.errors.WithLabelValues("half_plus_two", "Unavailable")))
+}
+
+func TestExporterInvalidInterval(t *testing.T) {
+	assert.Equal(t, 1, runMain(t, "exporter", "-check-interval=0"))
+}
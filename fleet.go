@@ -0,0 +1,175 @@
+//
+// Copyright 2020 Cody Collier <cody@telnet.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/codycollier/tfs-model-status-probe/probe"
+	"github.com/codycollier/tfs-model-status-probe/tfproto/tfproto"
+)
+
+// jsonFleetReport is the document written by fleet -output=json
+type jsonFleetReport struct {
+	Replicas        []*jsonReport      `json:"replicas"`
+	Models          []jsonFleetModel   `json:"models"`
+	Quorum          int                `json:"quorum"`
+	Decision        string             `json:"decision"`
+	ExitCode        int                `json:"exit_code"`
+	Category        string             `json:"category,omitempty"`
+	ProcessExitCode int                `json:"process_exit_code"` // after any exit code mapping
+	Error           string             `json:"error,omitempty"`
+	Timing          map[string]float64 `json:"timing"`
+}
+
+type jsonFleetModel struct {
+	Model     string             `json:"model"`
+	Available int                `json:"available"`
+	Divergent bool               `json:"divergent"`
+	Versions  map[string][]int64 `json:"versions"`
+}
+
+// Build the json report of a fleet check
+func newJSONFleetReport(config probe.Config, result *probe.FleetResult) *jsonFleetReport {
+	report := &jsonFleetReport{
+		Replicas: []*jsonReport{},
+		Models:   []jsonFleetModel{},
+		Quorum:   result.Quorum,
+		Decision: probe.ExitCodeText(result.ExitCode),
+		ExitCode: result.ExitCode,
+		Category: string(result.Category),
+		Timing:   map[string]float64{"total_ms": milliseconds(result.Latency)},
+	}
+	if result.Err != nil {
+		report.Error = result.Err.Error()
+	}
+	for _, replica := range result.Replicas {
+		replicaConfig := config
+		replicaConfig.Addr = replica.Addr
+		report.Replicas = append(report.Replicas, newJSONReport(replicaConfig, replica.Result))
+	}
+	for _, m := range result.Models {
+		report.Models = append(report.Models, jsonFleetModel{
+			Model:     m.Model.String(),
+			Available: m.Available,
+			Divergent: m.Divergent,
+			Versions:  m.Versions,
+		})
+	}
+	return report
+}
+
+// Describe a model on a replica for the fleet matrix
+func fleetCell(replica probe.ReplicaResult, i int) string {
+	if i >= len(replica.Result.Models) {
+		return probe.ExitCodeText(replica.Result.ExitCode)
+	}
+	r := replica.Result.Models[i]
+	if r.ExitCode == probe.ExitAvailable {
+		var versions []string
+		for _, res := range r.Response.GetModelVersionStatus() {
+			if res.State == tfproto.ModelVersionStatus_AVAILABLE {
+				versions = append(versions, fmt.Sprintf("v%v", res.Version))
+			}
+		}
+		sort.Strings(versions)
+		return strings.Join(versions, ",") + " AVAILABLE"
+	}
+	if r.Version != 0 {
+		return fmt.Sprintf("v%v %v", r.Version, r.State)
+	}
+	return probe.ExitCodeText(r.ExitCode)
+}
+
+// Write the replica by model matrix of a fleet check
+func writeFleetMatrix(w io.Writer, config probe.Config, result *probe.FleetResult) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	header := []string{"REPLICA"}
+	for _, model := range config.Models {
+		header = append(header, model.String())
+	}
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, replica := range result.Replicas {
+		row := []string{replica.Addr}
+		for i := range config.Models {
+			row = append(row, fleetCell(replica, i))
+		}
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// Run the fleet subcommand, returning the exit code
+func runFleet(args []string) int {
+	fs := subcommandFlags("fleet")
+	replicas := fs.String("replicas", "", "The replicas: host:port,host:port, dns:name:port for every A record, or srv:name for every SRV record")
+	quorum := fs.Int("quorum", 0, "The number of replicas which must have each model AVAILABLE (default all)")
+	fs.Parse(args)
+
+	exitCodes, err := newExitCodeMap(*flExitCodeProfile, *flExitCodeFile, *flExitCodeMap)
+	if err != nil {
+		log.Printf("Error: %v\n", err)
+		return probe.ExitInvalidConfig
+	}
+	config, err := configFromFlags()
+	if err == nil && *flOutput == "nagios" {
+		err = fmt.Errorf("the nagios output is not supported by fleet")
+	}
+	var addrs []string
+	if err == nil {
+		if *replicas == "" {
+			err = fmt.Errorf("the -replicas option is required")
+		} else {
+			addrs, err = probe.ResolveReplicas(context.Background(), *replicas)
+		}
+	}
+	var f *probe.Fleet
+	if err == nil {
+		config.Logger = nil
+		f, err = probe.NewFleet(config, addrs, *quorum)
+	}
+	if err != nil {
+		log.Printf("Error: %v\n", err)
+		return exitCodes.exitCode(probe.ExitInvalidConfig)
+	}
+	defer f.Close()
+
+	result := f.Check(context.Background())
+	if *flOutput == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		report := newJSONFleetReport(config, result)
+		report.ProcessExitCode = exitCodes.exitCode(result.ExitCode)
+		encoder.Encode(report)
+	} else {
+		writeFleetMatrix(os.Stdout, config, result)
+		if result.Err != nil {
+			log.Printf("Fleet: %v\n", result.Err)
+		} else {
+			log.Printf("Fleet: %v replicas consistent\n", len(result.Replicas))
+		}
+	}
+	return exitCodes.exitCode(result.ExitCode)
+}
@@ -0,0 +1,66 @@
+//
+// Copyright 2020 Cody Collier <cody@telnet.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/codycollier/tfs-model-status-probe/tfstest"
+)
+
+func TestFleet(t *testing.T) {
+	versions := func(vs ...int64) tfstest.Model {
+		model := tfstest.Model{Name: "half_plus_two"}
+		for _, v := range vs {
+			model.Versions = append(model.Versions, tfstest.Version{Version: v, Timeline: tfstest.Available()})
+		}
+		return model
+	}
+	a := tfstest.NewServer(versions(2))
+	defer a.Close()
+	b := tfstest.NewServer(versions(1))
+	defer b.Close()
+	c := tfstest.NewServer()
+	defer c.Close()
+
+	out, code := runMainOutput(t, "fleet", "-replicas="+a.Addr+","+b.Addr+","+c.Addr, "-model-name=half_plus_two", "-quorum=2")
+	assert.Equal(t, 26, code)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	assert.Len(t, lines, 4)
+	assert.Regexp(t, `^REPLICA\s+half_plus_two$`, lines[0])
+	assert.Contains(t, out, "v2 AVAILABLE")
+	assert.Contains(t, out, "v1 AVAILABLE")
+	assert.Contains(t, out, "model not found")
+
+	out, code = runMainOutput(t, "fleet", "-replicas="+a.Addr+","+c.Addr, "-model-name=half_plus_two", "-output=json")
+	assert.Equal(t, 27, code)
+	var report jsonFleetReport
+	assert.Nil(t, json.Unmarshal([]byte(out), &report))
+	assert.Equal(t, 2, report.Quorum)
+	assert.Len(t, report.Replicas, 2)
+	assert.Equal(t, 1, report.Models[0].Available)
+	assert.Equal(t, "fleet", report.Category)
+
+	assert.Equal(t, 0, runMain(t, "fleet", "-replicas="+a.Addr, "-model-name=half_plus_two"))
+	assert.Equal(t, 2, runMain(t, "fleet", "-replicas="+a.Addr+","+b.Addr, "-model-name=half_plus_two", "-exit-code-profile=nagios"))
+	assert.Equal(t, 1, runMain(t, "fleet", "-model-name=half_plus_two"))
+	assert.Equal(t, 1, runMain(t, "fleet", "-replicas="+a.Addr, "-quorum=2"))
+}
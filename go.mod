@@ -3,8 +3,10 @@ module github.com/codycollier/tfs-model-status-probe
 go 1.15
 
 require (
-	github.com/golang/protobuf v1.4.1
+	github.com/golang/protobuf v1.4.3
+	github.com/prometheus/client_golang v1.9.0
 	github.com/stretchr/testify v1.6.1
 	google.golang.org/grpc v1.33.2
 	google.golang.org/protobuf v1.25.0
+	gopkg.in/yaml.v3 v3.0.0-20200313102051-9f266ea9e77c
 )
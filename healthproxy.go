@@ -0,0 +1,218 @@
+//
+// Copyright 2020 Cody Collier <cody@telnet.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"github.com/codycollier/tfs-model-status-probe/probe"
+)
+
+// The most services a health proxy tracks, as any model can be asked for
+const maxHealthServices = 256
+
+// healthProxy serves grpc.health.v1.Health for TFS. The service name is a
+// model (name, name:version or name@label), or empty for the full check.
+// Statuses are refreshed by polling model status, and the grpc health server
+// streams the changes to watchers.
+type healthProxy struct {
+	*health.Server
+	prober *probe.Prober
+	mu     sync.Mutex
+	models map[string]probe.Model
+	last   map[string]healthpb.HealthCheckResponse_ServingStatus
+}
+
+func newHealthProxy(prober *probe.Prober) *healthProxy {
+	h := &healthProxy{
+		Server: health.NewServer(),
+		prober: prober,
+		models: make(map[string]probe.Model),
+		last:   make(map[string]healthpb.HealthCheckResponse_ServingStatus),
+	}
+	for _, model := range prober.Config().Models {
+		h.models[model.String()] = model
+	}
+	return h
+}
+
+func servingStatus(result *probe.Result) healthpb.HealthCheckResponse_ServingStatus {
+	if result.ExitCode == probe.ExitAvailable {
+		return healthpb.HealthCheckResponse_SERVING
+	}
+	return healthpb.HealthCheckResponse_NOT_SERVING
+}
+
+// Set the status of a service, logging changes
+func (h *healthProxy) setStatus(service string, result *probe.Result) {
+	serving := servingStatus(result)
+	h.mu.Lock()
+	previous, seen := h.last[service]
+	h.last[service] = serving
+	h.mu.Unlock()
+	if !seen || previous != serving {
+		log.Printf("Service %q: %v (%v)\n", service, serving, probe.ExitCodeText(result.ExitCode))
+	}
+	h.SetServingStatus(service, serving)
+}
+
+// Refresh the status of every tracked service
+func (h *healthProxy) poll(ctx context.Context) {
+	h.setStatus("", h.prober.Check(ctx))
+
+	h.mu.Lock()
+	services := make([]string, 0, len(h.models))
+	models := make([]probe.Model, 0, len(h.models))
+	for service, model := range h.models {
+		services = append(services, service)
+		models = append(models, model)
+	}
+	h.mu.Unlock()
+
+	// a failure to connect leaves every service NOT_SERVING
+	result := h.prober.CheckModels(ctx, models)
+	for i, service := range services {
+		exitCode := result.ExitCode
+		if result.Models != nil {
+			exitCode = result.Models[i].ExitCode
+		}
+		h.setStatus(service, &probe.Result{ExitCode: exitCode})
+	}
+}
+
+// Poll at the interval until the context is done
+func (h *healthProxy) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		h.poll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Start tracking a model on first use, with an immediate check
+func (h *healthProxy) track(ctx context.Context, service string) error {
+	if service == "" {
+		return nil
+	}
+	h.mu.Lock()
+	_, tracked := h.models[service]
+	full := len(h.models) >= maxHealthServices
+	h.mu.Unlock()
+	if tracked {
+		return nil
+	}
+	if full {
+		return status.Errorf(codes.ResourceExhausted, "too many services tracked (%v)", maxHealthServices)
+	}
+	model, err := probe.ParseModel(service)
+	if err != nil {
+		return status.Error(codes.NotFound, err.Error())
+	}
+
+	h.setStatus(service, h.prober.CheckModels(ctx, []probe.Model{model}))
+	h.mu.Lock()
+	h.models[service] = model
+	h.mu.Unlock()
+	return nil
+}
+
+func (h *healthProxy) Check(ctx context.Context, in *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	if err := h.track(ctx, in.Service); err != nil {
+		return nil, err
+	}
+	return h.Server.Check(ctx, in)
+}
+
+func (h *healthProxy) Watch(in *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	if err := h.track(stream.Context(), in.Service); err != nil {
+		return err
+	}
+	return h.Server.Watch(in, stream)
+}
+
+// Run the health-proxy subcommand until interrupted, returning the exit code
+func runHealthProxy(args []string) int {
+	fs := subcommandFlags("health-proxy")
+	listen := fs.String("listen", ":8510", "The host:port to serve grpc.health.v1.Health on")
+	interval := fs.Duration("check-interval", time.Second*5, "Interval between model status checks")
+	fs.Parse(args)
+
+	config, err := configFromFlags()
+	if err == nil && config.Wait {
+		err = errors.New("the -wait option is not supported by health-proxy")
+	}
+	if err == nil && *interval <= 0 {
+		err = errors.New("the -check-interval must be positive")
+	}
+	var p *probe.Prober
+	if err == nil {
+		config.Logger = nil
+		p, err = probe.New(config)
+	}
+	if err != nil {
+		log.Printf("Error: %v\n", err)
+		return probe.ExitInvalidConfig
+	}
+	defer p.Close()
+
+	listener, err := net.Listen("tcp", *listen)
+	if err != nil {
+		log.Printf("Error listening: %v\n", err)
+		return probe.ExitInvalidConfig
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	h := newHealthProxy(p)
+	go h.run(ctx, *interval)
+
+	server := grpc.NewServer()
+	healthpb.RegisterHealthServer(server, h)
+	go func() {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+		<-signals
+		h.Shutdown()
+		server.Stop()
+	}()
+
+	log.Printf("Serving grpc health for %v on %v\n", p.Config().Addr, *listen)
+	if err := server.Serve(listener); err != nil {
+		log.Printf("Error serving: %v\n", err)
+		return probe.ExitInvalidConfig
+	}
+	return probe.ExitAvailable
+}
@@ -0,0 +1,137 @@
+//
+// Copyright 2020 Cody Collier <cody@telnet.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/codycollier/tfs-model-status-probe/probe"
+)
+
+// Serve the health proxy over an in-memory connection and return a client
+func healthProxyClient(t *testing.T, h *healthProxy) (healthpb.HealthClient, func()) {
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	healthpb.RegisterHealthServer(server, h)
+	go server.Serve(listener)
+
+	dialer := func(ctx context.Context, addr string) (net.Conn, error) {
+		return listener.Dial()
+	}
+	conn, err := grpc.Dial("bufconn", grpc.WithContextDialer(dialer), grpc.WithInsecure())
+	assert.Nil(t, err)
+	return healthpb.NewHealthClient(conn), func() {
+		conn.Close()
+		server.Stop()
+	}
+}
+
+func TestHealthProxyCheck(t *testing.T) {
+	tfs := fakeRESTServer(map[string]string{"half_plus_two": "AVAILABLE", "resnet": "LOADING"})
+	defer tfs.Close()
+
+	p, err := probe.New(probe.Config{
+		Addr:     strings.TrimPrefix(tfs.URL, "http://"),
+		Protocol: "rest",
+		Models:   []probe.Model{{Name: "half_plus_two"}},
+	})
+	assert.Nil(t, err)
+	h := newHealthProxy(p)
+	h.poll(context.Background())
+	client, stop := healthProxyClient(t, h)
+	defer stop()
+
+	expected := map[string]healthpb.HealthCheckResponse_ServingStatus{
+		"":                healthpb.HealthCheckResponse_SERVING,
+		"half_plus_two":   healthpb.HealthCheckResponse_SERVING,
+		"half_plus_two:1": healthpb.HealthCheckResponse_SERVING,
+		"resnet":          healthpb.HealthCheckResponse_NOT_SERVING,
+		"missing":         healthpb.HealthCheckResponse_NOT_SERVING,
+	}
+	for service, serving := range expected {
+		response, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		assert.Nil(t, err)
+		assert.Equal(t, serving, response.Status, service)
+	}
+
+	_, err = client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "resnet@"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestHealthProxyWatch(t *testing.T) {
+	var state atomic.Value
+	state.Store("LOADING")
+	tfs := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"model_version_status": [{"version": "1", "state": "%v"}]}`, state.Load())
+	}))
+	defer tfs.Close()
+
+	p, err := probe.New(probe.Config{Addr: strings.TrimPrefix(tfs.URL, "http://"), Protocol: "rest"})
+	assert.Nil(t, err)
+	h := newHealthProxy(p)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go h.run(ctx, time.Millisecond*10)
+	client, stop := healthProxyClient(t, h)
+	defer stop()
+
+	stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{Service: "half_plus_two"})
+	assert.Nil(t, err)
+	response, err := stream.Recv()
+	assert.Nil(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, response.Status)
+
+	// The transition is streamed once the model is AVAILABLE
+	state.Store("AVAILABLE")
+	response, err = stream.Recv()
+	assert.Nil(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, response.Status)
+}
+
+func TestHealthProxyDialFailure(t *testing.T) {
+	p, err := probe.New(probe.Config{Addr: "127.0.0.1:1", ConnectTimeout: 100 * time.Millisecond, Models: []probe.Model{{Name: "half_plus_two"}}})
+	assert.Nil(t, err)
+	h := newHealthProxy(p)
+	h.poll(context.Background())
+	client, stop := healthProxyClient(t, h)
+	defer stop()
+
+	for _, service := range []string{"", "half_plus_two"} {
+		response, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		assert.Nil(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, response.Status, service)
+	}
+}
+
+func TestHealthProxyInvalidInterval(t *testing.T) {
+	assert.Equal(t, 1, runMain(t, "health-proxy", "-check-interval=-1s"))
+}
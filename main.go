@@ -18,159 +18,273 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
+	"fmt"
+	"io/ioutil"
 	"log"
 	"os"
+	"strings"
 	"time"
 
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
-
+	"github.com/codycollier/tfs-model-status-probe/probe"
 	"github.com/codycollier/tfs-model-status-probe/tfproto/tfproto"
 )
 
 var (
-	flModelName      = flag.String("model-name", "default", "The name of the model")
-	flModelVersion   = flag.Int64("model-version", 0, "The version of the model")
-	flAddr           = flag.String("addr", "localhost:9000", "The hostname:port to check")
-	flConnectTimeout = flag.Duration("connect-timeout", time.Second*3, "Timeout for making connection")
-	flRpcTimeout     = flag.Duration("rpc-timeout", time.Second*10, "Timeout for rpc call")
+	flModelNames       = &modelTargets{targets: []probe.Model{{Name: "default"}}}
+	flModelVersion     = flag.Int64("model-version", 0, "The version of the model")
+	flModelLabel       = flag.String("model-version-label", "", "The version label of the model (ex: stable, canary)")
+	flAddr             = flag.String("addr", "localhost:9000", "The hostname:port to check")
+	flConnectTimeout   = flag.Duration("connect-timeout", time.Second*3, "Timeout for making connection")
+	flRpcTimeout       = flag.Duration("rpc-timeout", time.Second*10, "Timeout for rpc call")
+	flProtocol         = flag.String("protocol", "grpc", "The api to use: grpc (port 8500) or rest (port 8501)")
+	flModelConfig      = flag.String("model-config-file", "", "Check the models listed in a TFS model config file")
+	flVersionFilter    = flag.String("version-filter", "server", "Filter by -model-version on the server, or on the client for older servers: server or client")
+	flMinAvailable     = flag.Int("min-available", 0, "The minimum number of models which must be AVAILABLE (default all)")
+	flVersionSelection = flag.String("version-selection", "any-available", "The version to check when none is given: any-available, highest, all-available or most-advanced")
+	flMinVersion       = flag.Int64("min-version", 0, "Fail when the AVAILABLE version is below this")
+	flMaxVersion       = flag.Int64("max-version", 0, "Fail when the AVAILABLE version is above this")
+	flExpectLatest     = flag.Bool("expect-latest-available", false, "Fail unless the highest version present is AVAILABLE")
+	flMaxAvailable     = flag.Int("max-available-versions", 0, "Fail when more versions than this are AVAILABLE")
+	flMaxVersionAge    = flag.Duration("max-version-age", 0, "Fail when the AVAILABLE version, read as a timestamp, is older than this")
+	flVersionLayout    = flag.String("version-time-layout", "", "The timestamp layout of versions for -max-version-age, such as YYYYMMDDhhmm (default unix seconds)")
+	flWait             = flag.Bool("wait", false, "Wait until the models are AVAILABLE, polling with backoff")
+	flWaitTimeout      = flag.Duration("wait-timeout", time.Minute*5, "Overall deadline when waiting")
+	flPollInterval     = flag.Duration("poll-interval", time.Second, "Initial interval between polls when waiting")
+	flMaxPollInterval  = flag.Duration("max-poll-interval", time.Second*30, "Maximum interval between polls when waiting")
+	flReconnect        = flag.Bool("reconnect", true, "Reconnect after connection or rpc failures when waiting; when false, a failure to connect ends the wait")
+	flPredictRequest   = flag.String("predict-request", "", "Send this PredictRequest (.json or text proto) once the model is AVAILABLE")
+	flPredictExpect    = flag.String("predict-expect", "", "Expected PredictResponse outputs (.json or text proto) for -predict-request")
+	flPredictTolerance = flag.Float64("predict-tolerance", 1e-6, "Tolerance when comparing -predict-expect values")
+	flExpectSignature  = flag.String("expect-signature", "", "Compare the live signature_def against this SignatureDefMap (.json or text proto)")
+	flOutput           = flag.String("output", "text", "Output format: text (log lines on stderr), json (a single document on stdout) or nagios (a plugin status line on stdout)")
+	flWarningLatency   = flag.Duration("warning-latency", 0, "Warn when the rpc time exceeds this (nagios output)")
+	flCriticalLatency  = flag.Duration("critical-latency", 0, "Critical when the rpc time exceeds this (nagios output)")
+	flExitCodeProfile  = flag.String("exit-code-profile", "default", "Exit code profile: default or nagios (0 ok, 1 warning, 2 critical, 3 unknown)")
+	flExitCodeFile     = flag.String("exit-code-file", "", "A yaml or json file mapping outcomes or categories to exit codes, applied over the profile")
+	flExitCodeMap      = flag.String("exit-code-map", "", "Outcome or category to exit code overrides (ex: state_unloading=0,connection=69)")
+	flTextfile         = flag.String("textfile", "", "Also write the result in prometheus text format to this file, for the node_exporter textfile collector")
+	flTLS              = flag.Bool("tls", false, "Use TLS when connecting")
+	flTLSCACert        = flag.String("tls-ca-cert", "", "Path to a CA bundle used to verify the server (TLS)")
+	flTLSClientCert    = flag.String("tls-client-cert", "", "Path to a client certificate for mutual TLS")
+	flTLSClientKey     = flag.String("tls-client-key", "", "Path to a client private key for mutual TLS")
+	flTLSServerName    = flag.String("tls-server-name", "", "Override the server name used to verify the server certificate (TLS)")
+	flTLSNoVerify      = flag.Bool("tls-no-verify", false, "Skip verification of the server certificate (TLS)")
 )
 
-// Call ModelService.GetModelStatus() and return response
-func callModelStatus(ctx context.Context, client tfproto.ModelServiceClient, model string) (*tfproto.GetModelStatusResponse, error) {
-	request := &tfproto.GetModelStatusRequest{
-		ModelSpec: &tfproto.ModelSpec{
-			Name: model,
-		},
+func init() {
+	flag.Var(flModelNames, "model-name", "The name of the model, or name:version or name@label (repeatable)")
+}
+
+// modelTargets implements flag.Value for the repeatable -model-name flag.
+// The defaults are replaced, not appended to, on first use of the flag.
+type modelTargets struct {
+	targets []probe.Model
+	set     bool
+}
+
+func (m *modelTargets) String() string {
+	if m == nil {
+		return ""
+	}
+	names := make([]string, len(m.targets))
+	for i, target := range m.targets {
+		names[i] = target.String()
 	}
-	response, err := client.GetModelStatus(ctx, request)
+	return strings.Join(names, ",")
+}
+
+func (m *modelTargets) Set(value string) error {
+	target, err := probe.ParseModel(value)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	if !m.set {
+		m.targets = nil
+		m.set = true
 	}
-	return response, nil
+	m.targets = append(m.targets, target)
+	return nil
 }
 
-// Parse the proto msg response and map to an appropriate return value
-func checkServableResponse(response *tfproto.GetModelStatusResponse, modelVersion int64) int {
+// Build the probe config from the command line args
+func configFromFlags() (probe.Config, error) {
+	config := probe.Config{
+		Addr:                  *flAddr,
+		Protocol:              *flProtocol,
+		MinAvailable:          *flMinAvailable,
+		VersionFilter:         *flVersionFilter,
+		Selection:             *flVersionSelection,
+		ConnectTimeout:        *flConnectTimeout,
+		RPCTimeout:            *flRpcTimeout,
+		Wait:                  *flWait,
+		WaitTimeout:           *flWaitTimeout,
+		PollInterval:          *flPollInterval,
+		MaxPollInterval:       *flMaxPollInterval,
+		Reconnect:             *flReconnect,
+		PredictTolerance:      *flPredictTolerance,
+		MinVersion:            *flMinVersion,
+		MaxVersion:            *flMaxVersion,
+		ExpectLatestAvailable: *flExpectLatest,
+		MaxAvailableVersions:  *flMaxAvailable,
+		MaxVersionAge:         *flMaxVersionAge,
+		VersionTimeLayout:     *flVersionLayout,
+		Logger:                log.New(os.Stderr, "", log.LstdFlags),
+	}
+	switch *flOutput {
+	case "text":
+	case "json", "nagios":
+		config.Logger = log.New(ioutil.Discard, "", 0)
+	default:
+		return config, fmt.Errorf("unknown output format: %v", *flOutput)
+	}
 
-	// Ensure non-empty response
-	if len(response.ModelVersionStatus) == 0 {
-		log.Println("Empty response")
-		return 11
+	// a version and a version label are mutually exclusive
+	modelVersion := *flModelVersion
+	modelLabel := *flModelLabel
+	if modelVersion != 0 && modelLabel != "" {
+		return config, errors.New("the -model-version and -model-version-label options are mutually exclusive")
 	}
 
-	// Get the state for the noted version. If no version, take any AVAILABLE.
-	var status tfproto.ModelVersionStatus_State
-	statusFound := false
-	if modelVersion == 0 {
-		for _, res := range response.ModelVersionStatus {
-			if res.State == tfproto.ModelVersionStatus_AVAILABLE {
-				status = res.State
-				statusFound = true
-				break
+	// the -model-version or -model-version-label applies to any model given
+	// without a version or label of its own
+	if flModelNames.set || *flModelConfig == "" {
+		for _, target := range flModelNames.targets {
+			if target.Version == 0 && target.Label == "" {
+				target.Version = modelVersion
+				target.Label = modelLabel
 			}
+			config.Models = append(config.Models, target)
 		}
-		// when no version is specified, and no model with state available is
-		// found, arbitrarily fallback to first (latest?) item in array
-		if !statusFound {
-			status = response.ModelVersionStatus[0].State
-			statusFound = true
+	}
+
+	// models from a config file are checked against their version policy
+	if *flModelConfig != "" {
+		configModels, err := probe.ReadModelConfigFile(*flModelConfig)
+		if err != nil {
+			return config, fmt.Errorf("reading model config file: %v", err)
 		}
-	} else {
-		for _, res := range response.ModelVersionStatus {
-			if modelVersion == res.Version {
-				status = res.State
-				statusFound = true
-				break
-			}
+		config.Models = append(config.Models, configModels...)
+	}
+
+	// tls options are only valid alongside -tls
+	if !*flTLS && (*flTLSCACert != "" || *flTLSClientCert != "" || *flTLSClientKey != "" || *flTLSServerName != "" || *flTLSNoVerify) {
+		return config, errors.New("the -tls-* options require -tls")
+	}
+	if *flTLS {
+		var err error
+		config.TLS, err = probe.NewTLSConfig(*flTLSCACert, *flTLSClientCert, *flTLSClientKey, *flTLSServerName, *flTLSNoVerify)
+		if err != nil {
+			return config, fmt.Errorf("building tls config: %v", err)
 		}
 	}
 
-	// No matching version found? Return early.
-	if !statusFound {
-		log.Printf("No matching response found for version: %v\n", modelVersion)
-		return 12
-	}
-
-	// Map servable states to return value
-	// https://github.com/tensorflow/serving/blob/master/tensorflow_serving/apis/get_model_status.proto
-	var retval int
-	switch status {
-	case tfproto.ModelVersionStatus_AVAILABLE:
-		// servable is up and ready
-		log.Println("Servable state is AVAILABLE")
-		retval = 0
-	case tfproto.ModelVersionStatus_UNKNOWN:
-		log.Println("Servable state is UNKNOWN")
-		retval = 30
-	case tfproto.ModelVersionStatus_START:
-		log.Println("Servable state is START")
-		retval = 31
-	case tfproto.ModelVersionStatus_LOADING:
-		log.Println("Servable state is LOADING")
-		retval = 32
-	case tfproto.ModelVersionStatus_UNLOADING:
-		log.Println("Servable state is UNLOADING")
-		retval = 33
-	case tfproto.ModelVersionStatus_END:
-		log.Println("Servable state is END")
-		retval = 34
-	default:
-		log.Println("Servable state is unexpected")
-		retval = 100 // unexpected
+	// optional signature check and inference smoke test
+	if *flExpectSignature != "" {
+		config.ExpectSignature = &tfproto.SignatureDefMap{}
+		if err := probe.ReadProtoFile(*flExpectSignature, config.ExpectSignature); err != nil {
+			return config, fmt.Errorf("reading expected signature: %v", err)
+		}
+	}
+	if *flPredictRequest != "" {
+		config.PredictRequest = &tfproto.PredictRequest{}
+		if err := probe.ReadProtoFile(*flPredictRequest, config.PredictRequest); err != nil {
+			return config, fmt.Errorf("reading predict request: %v", err)
+		}
+		if *flPredictExpect != "" {
+			config.PredictExpect = &tfproto.PredictResponse{}
+			if err := probe.ReadProtoFile(*flPredictExpect, config.PredictExpect); err != nil {
+				return config, fmt.Errorf("reading predict expectations: %v", err)
+			}
+		}
 	}
 
-	return retval
+	return config, nil
 }
 
 func main() {
 
+	// Run a subcommand, which shares the probe flags
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "serve":
+			os.Exit(runServe(os.Args[2:]))
+		case "health-proxy":
+			os.Exit(runHealthProxy(os.Args[2:]))
+		case "exporter":
+			os.Exit(runExporter(os.Args[2:]))
+		case "fleet":
+			os.Exit(runFleet(os.Args[2:]))
+		case "watch":
+			os.Exit(runWatch(os.Args[2:]))
+		case "mock-server":
+			os.Exit(runMockServer(os.Args[2:]))
+		}
+	}
+
 	// Process command line args
 	flag.Parse()
-	addr := *flAddr
-	modelName := *flModelName
-	modelVersion := *flModelVersion
-	connectTimeout := *flConnectTimeout
-	rpcTimeout := *flRpcTimeout
-
-	// set a timeout on the connection
-	ctxDial, cancelDial := context.WithTimeout(context.Background(), connectTimeout)
-	defer cancelDial()
-
-	// grpc connection
-	var opts []grpc.DialOption
-	opts = append(opts, grpc.WithInsecure())
-	opts = append(opts, grpc.WithBlock())
-	conn, err := grpc.DialContext(ctxDial, addr, opts...)
+
+	// the nagios output implies the nagios exit codes
+	profile := *flExitCodeProfile
+	if *flOutput == "nagios" && profile == "default" {
+		profile = "nagios"
+	}
+	exitCodes, err := newExitCodeMap(profile, *flExitCodeFile, *flExitCodeMap)
 	if err != nil {
-		log.Printf("Error dialing grpc service: %v\n", err)
-		os.Exit(2)
+		exitCodes = exitCodeProfiles[profile]
+		if *flOutput == "nagios" {
+			exitCodes = exitCodeProfiles["nagios"]
+		}
+	}
+	config := probe.Config{}
+	if err == nil {
+		config, err = configFromFlags()
+	}
+	if err == nil && *flCriticalLatency > 0 && *flWarningLatency > *flCriticalLatency {
+		err = errors.New("the -warning-latency exceeds the -critical-latency")
+	}
+	var p *probe.Prober
+	if err == nil {
+		p, err = probe.New(config)
 	}
-	defer conn.Close()
-
-	// grpc client
-	client := tfproto.NewModelServiceClient(conn)
-
-	// set a timeout on the rpc
-	ctxRpc, cancelRpc := context.WithTimeout(context.Background(), rpcTimeout)
-	defer cancelRpc()
-
-	// call model status
-	modelStatusResponse, err := callModelStatus(ctxRpc, client, modelName)
-	log.Printf("ModelStatusResponse: %v\n", modelStatusResponse)
 	if err != nil {
-		if status.Code(err) == codes.NotFound {
-			log.Printf("Model not found: %v\n", err)
-			os.Exit(10)
+		result := &probe.Result{
+			ExitCode: probe.ExitInvalidConfig,
+			Category: probe.CategoryConfig,
+			Err:      err,
 		}
-		log.Printf("Error calling tfs: %v\n", err)
-		os.Exit(3)
+		if *flTextfile != "" {
+			if err := writeTextfile(*flTextfile, result); err != nil {
+				log.Printf("Error writing textfile: %v\n", err)
+			}
+		}
+		switch *flOutput {
+		case "json":
+			writeJSONReport(os.Stdout, config, result, exitCodes.exitCode(probe.ExitInvalidConfig))
+		case "nagios":
+			os.Exit(writeNagiosOutput(os.Stdout, result, exitCodes, 0, 0))
+		default:
+			log.Printf("Error: %v\n", err)
+		}
+		os.Exit(exitCodes.exitCode(probe.ExitInvalidConfig))
 	}
 
-	// check response for servable status
-	retval := checkServableResponse(modelStatusResponse, modelVersion)
-	os.Exit(retval)
+	// check the models, and exit with the mapped return value
+	result := p.Check(context.Background())
+	p.Close()
+	if *flTextfile != "" {
+		if err := writeTextfile(*flTextfile, result); err != nil {
+			log.Printf("Error writing textfile: %v\n", err)
+		}
+	}
+	switch *flOutput {
+	case "json":
+		writeJSONReport(os.Stdout, p.Config(), result, exitCodes.exitCode(result.ExitCode))
+	case "nagios":
+		os.Exit(writeNagiosOutput(os.Stdout, result, exitCodes, *flWarningLatency, *flCriticalLatency))
+	}
+	os.Exit(exitCodes.exitCode(result.ExitCode))
 
 }
@@ -17,223 +17,156 @@
 package main
 
 import (
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
+	"github.com/codycollier/tfs-model-status-probe/probe"
 	"github.com/codycollier/tfs-model-status-probe/tfproto/tfproto"
+	"github.com/codycollier/tfs-model-status-probe/tfstest"
 )
 
-func TestResponseEmpty(t *testing.T) {
-	request := &tfproto.GetModelStatusResponse{}
-	retval := checkServableResponse(request, 0)
-	assert.Equal(t, 11, retval, "Expecting response code for empty")
-}
+func TestModelTargetsFlag(t *testing.T) {
+	targets := &modelTargets{targets: []probe.Model{{Name: "default"}}}
+	assert.Equal(t, "default", targets.String())
 
-func TestResponseMissingVersion(t *testing.T) {
-	request := &tfproto.GetModelStatusResponse{
-		ModelVersionStatus: []*tfproto.ModelVersionStatus{
-			{
-				Version: 100,
-				State:   tfproto.ModelVersionStatus_UNKNOWN,
-			},
-		},
-	}
-	retval := checkServableResponse(request, 300)
-	assert.Equal(t, 12, retval, "Expecting response code for empty")
+	// The default is replaced by the first value
+	assert.Nil(t, targets.Set("half_plus_two"))
+	assert.Nil(t, targets.Set("half_plus_three:7"))
+	assert.Equal(t, "half_plus_two,half_plus_three:7", targets.String())
 }
 
-func TestResponseStateUnknown(t *testing.T) {
-	request := &tfproto.GetModelStatusResponse{
-		ModelVersionStatus: []*tfproto.ModelVersionStatus{
-			{
-				Version: 123,
-				State:   tfproto.ModelVersionStatus_UNKNOWN,
-			},
-		},
-	}
-	retval := checkServableResponse(request, 0)
-	assert.Equal(t, 30, retval, "Expecting response code for state Unknown")
+// Run main() in a subprocess with the args, returning its exit code
+func runMain(t *testing.T, args ...string) int {
+	_, code := runMainOutput(t, args...)
+	return code
 }
 
-func TestResponseStateStart(t *testing.T) {
-	request := &tfproto.GetModelStatusResponse{
-		ModelVersionStatus: []*tfproto.ModelVersionStatus{
-			{
-				Version: 123,
-				State:   tfproto.ModelVersionStatus_START,
-			},
-		},
+// Run main() in a subprocess with the args, returning its stdout and exit code
+func runMainOutput(t *testing.T, args ...string) (string, int) {
+	cmd := exec.Command(os.Args[0], "-test.run=^TestMainProcess$")
+	cmd.Env = append(os.Environ(), "TEST_MAIN_ARGS="+strings.Join(args, "\n"))
+	out, err := cmd.Output()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return string(out), exitErr.ExitCode()
 	}
-	retval := checkServableResponse(request, 0)
-	assert.Equal(t, 31, retval, "Expecting response code for state Start")
+	assert.Nil(t, err)
+	return string(out), 0
 }
 
-func TestResponseStateLoading(t *testing.T) {
-	request := &tfproto.GetModelStatusResponse{
-		ModelVersionStatus: []*tfproto.ModelVersionStatus{
-			{
-				Version: 123,
-				State:   tfproto.ModelVersionStatus_LOADING,
-			},
-		},
+// The subprocess of runMain
+func TestMainProcess(t *testing.T) {
+	args, ok := os.LookupEnv("TEST_MAIN_ARGS")
+	if !ok {
+		t.Skip("only run as a subprocess of runMain")
 	}
-	retval := checkServableResponse(request, 0)
-	assert.Equal(t, 32, retval, "Expecting response code for Loading")
+	os.Args = append([]string{"tfs_model_status_probe"}, strings.Split(args, "\n")...)
+	main()
 }
 
-func TestResponseStateUnloading(t *testing.T) {
-	request := &tfproto.GetModelStatusResponse{
-		ModelVersionStatus: []*tfproto.ModelVersionStatus{
-			{
-				Version: 123,
-				State:   tfproto.ModelVersionStatus_UNLOADING,
-			},
-		},
+func TestMainExitCodes(t *testing.T) {
+	loading := []tfstest.Step{{State: tfproto.ModelVersionStatus_LOADING}}
+	server := tfstest.NewServer(
+		tfstest.Model{Name: "half_plus_two", Versions: []tfstest.Version{{Version: 1, Timeline: tfstest.Available()}}},
+		tfstest.Model{Name: "resnet", Versions: []tfstest.Version{{Version: 1, Timeline: loading}}},
+		tfstest.Model{Name: "warming", Versions: []tfstest.Version{{Version: 1, Timeline: append(loading,
+			tfstest.Step{After: 300 * time.Millisecond, State: tfproto.ModelVersionStatus_AVAILABLE})}}},
+		tfstest.Model{Name: "broken", Versions: []tfstest.Version{{Version: 1, Timeline: []tfstest.Step{
+			{State: tfproto.ModelVersionStatus_END, ErrorCode: tfproto.Code_INVALID_ARGUMENT},
+		}}}},
+		tfstest.Model{Name: "unavailable", Err: status.Error(codes.Unavailable, "overloaded")},
+		tfstest.Model{Name: "hang", Hang: true},
+	)
+	defer server.Close()
+	closed := tfstest.NewServer()
+	closed.Close()
+
+	addr := "-addr=" + server.Addr
+	expected := []struct {
+		args []string
+		code int
+	}{
+		{[]string{addr, "-model-name=half_plus_two"}, 0},
+		{[]string{addr, "-model-name=half_plus_two:1"}, 0},
+		{[]string{addr, "-model-name=half_plus_two:2"}, 12},
+		{[]string{addr, "-model-name=half_plus_two", "-min-version=2"}, 21},
+		{[]string{addr, "-model-name=half_plus_two", "-min-version=1", "-max-version=1", "-expect-latest-available"}, 0},
+		{[]string{addr, "-min-version=3", "-max-version=2"}, 1},
+		{[]string{addr, "-model-name=half_plus_two", "-max-version-age=24h"}, 28},
+		{[]string{addr, "-model-name=resnet"}, 32},
+		{[]string{addr, "-model-name=missing"}, 10},
+		{[]string{addr, "-model-name=missing", "-model-version=5"}, 10},
+		{[]string{addr, "-model-name=half_plus_two", "-model-version=5"}, 12},
+		{[]string{addr, "-model-name=broken"}, 63},
+		{[]string{addr, "-model-name=unavailable"}, 3},
+		{[]string{addr, "-model-name=hang", "-rpc-timeout=100ms"}, 3},
+		{[]string{addr, "-model-name=half_plus_two", "-model-name=resnet", "-min-available=1"}, 0},
+		{[]string{addr, "-model-name=warming", "-wait", "-poll-interval=50ms"}, 0},
+		{[]string{addr, "-model-name=resnet", "-wait", "-wait-timeout=200ms", "-poll-interval=50ms"}, 14},
+		{[]string{addr, "-model-name=resnet", "-exit-code-profile=nagios"}, 1},
+		{[]string{addr, "-model-name=resnet", "-exit-code-map=state_loading=0"}, 0},
+		{[]string{addr, "-exit-code-map=bogus=0"}, 1},
+		{[]string{addr, "-protocol=carrier-pigeon"}, 1},
+		{[]string{addr, "-protocol=carrier-pigeon", "-exit-code-profile=nagios"}, 3},
+		{[]string{"-addr=" + closed.Addr, "-connect-timeout=200ms"}, 2},
+		{[]string{"-addr=" + closed.Addr, "-connect-timeout=100ms", "-wait", "-wait-timeout=5s", "-reconnect=false"}, 2},
+		{[]string{"-addr=" + closed.Addr, "-wait", "-poll-interval=-1s"}, 1},
 	}
-	retval := checkServableResponse(request, 0)
-	assert.Equal(t, 33, retval, "Expecting response code for state Unloading")
-}
-
-func TestResponseStateEnd(t *testing.T) {
-	request := &tfproto.GetModelStatusResponse{
-		ModelVersionStatus: []*tfproto.ModelVersionStatus{
-			{
-				Version: 123,
-				State:   tfproto.ModelVersionStatus_END,
-			},
-		},
+	for _, e := range expected {
+		assert.Equal(t, e.code, runMain(t, e.args...), strings.Join(e.args, " "))
 	}
-	retval := checkServableResponse(request, 0)
-	assert.Equal(t, 34, retval, "Expecting response code for state End")
 }
 
-func TestResponseStateAvailable(t *testing.T) {
-	request := &tfproto.GetModelStatusResponse{
-		ModelVersionStatus: []*tfproto.ModelVersionStatus{
-			{
-				Version: 123,
-				State:   tfproto.ModelVersionStatus_AVAILABLE,
-			},
-		},
-	}
-	retval := checkServableResponse(request, 0)
-	assert.Equal(t, 0, retval, "Expecting response code for state Available")
+func TestMainNagiosOutput(t *testing.T) {
+	server := tfstest.NewServer(
+		tfstest.Model{Name: "half_plus_two", Versions: []tfstest.Version{{Version: 123, Timeline: tfstest.Available()}}},
+		tfstest.Model{Name: "slow", Latency: 100 * time.Millisecond, Versions: []tfstest.Version{{Version: 1, Timeline: tfstest.Available()}}},
+	)
+	defer server.Close()
+	addr := "-addr=" + server.Addr
+
+	out, code := runMainOutput(t, addr, "-model-name=half_plus_two", "-output=nagios", "-warning-latency=500ms", "-critical-latency=1s")
+	assert.Equal(t, 0, code)
+	assert.Regexp(t, `^TFS OK - half_plus_two v123 AVAILABLE \| rpc_time=\d+\.\d{3}s;0.5;1 dial_time=\S+ time=\S+\n$`, out)
+
+	out, code = runMainOutput(t, addr, "-model-name=slow", "-output=nagios", "-warning-latency=50ms")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, out, "TFS WARNING - slow v1 AVAILABLE, rpc time")
+
+	out, code = runMainOutput(t, addr, "-model-name=half_plus_two", "-output=nagios", "-min-version=200")
+	assert.Equal(t, 2, code)
+	assert.Contains(t, out, "TFS CRITICAL - half_plus_two v123 AVAILABLE, version below the minimum |")
+
+	out, code = runMainOutput(t, addr, "-model-name=missing", "-output=nagios")
+	assert.Equal(t, 2, code)
+	assert.Contains(t, out, "TFS CRITICAL - missing: model not found")
+
+	out, code = runMainOutput(t, addr, "-output=nagios", "-warning-latency=2s", "-critical-latency=1s")
+	assert.Equal(t, 3, code)
+	assert.Contains(t, out, "TFS UNKNOWN - invalid options: the -warning-latency exceeds the -critical-latency")
+
+	out, code = runMainOutput(t, addr, "-output=nagios", "-exit-code-profile=bogus")
+	assert.Equal(t, 3, code)
+	assert.Contains(t, out, "TFS UNKNOWN - invalid options: ")
 }
 
-func TestResponseStateAvailableOnNoVersion(t *testing.T) {
-
-	// Ensure success when arbitrary version is available (ex: after a rollback)
-	request := &tfproto.GetModelStatusResponse{
-		ModelVersionStatus: []*tfproto.ModelVersionStatus{
-			{
-				Version: 101,
-				State:   tfproto.ModelVersionStatus_END,
-			},
-			{
-				Version: 98,
-				State:   tfproto.ModelVersionStatus_END,
-			},
-			{
-				Version: 301,
-				State:   tfproto.ModelVersionStatus_AVAILABLE,
-			},
-			{
-				Version: 303,
-				State:   tfproto.ModelVersionStatus_END,
-			},
-		},
-	}
-	retval := checkServableResponse(request, 0)
-	assert.Equal(t, 0, retval)
-
-	// Ensure order is not relevant
-	request = &tfproto.GetModelStatusResponse{
-		ModelVersionStatus: []*tfproto.ModelVersionStatus{
-			{
-				Version: 101,
-				State:   tfproto.ModelVersionStatus_AVAILABLE,
-			},
-			{
-				Version: 301,
-				State:   tfproto.ModelVersionStatus_END,
-			},
-		},
-	}
-	retval = checkServableResponse(request, 0)
-	assert.Equal(t, 0, retval)
-
-	request = &tfproto.GetModelStatusResponse{
-		ModelVersionStatus: []*tfproto.ModelVersionStatus{
-			{
-				Version: 301,
-				State:   tfproto.ModelVersionStatus_END,
-			},
-			{
-				Version: 101,
-				State:   tfproto.ModelVersionStatus_AVAILABLE,
-			},
-		},
-	}
-	retval = checkServableResponse(request, 0)
-	assert.Equal(t, 0, retval)
-
-	// Ensure still fails if there is no version which is available
-	request = &tfproto.GetModelStatusResponse{
-		ModelVersionStatus: []*tfproto.ModelVersionStatus{
-			{
-				Version: 101,
-				State:   tfproto.ModelVersionStatus_END,
-			},
-			{
-				Version: 301,
-				State:   tfproto.ModelVersionStatus_END,
-			},
-			{
-				Version: 303,
-				State:   tfproto.ModelVersionStatus_END,
-			},
-		},
-	}
-	retval = checkServableResponse(request, 0)
-	assert.Equal(t, 34, retval)
-
-}
-
-func TestResponseStateAvailableOnSpecificVersion(t *testing.T) {
-
-	request := &tfproto.GetModelStatusResponse{
-		ModelVersionStatus: []*tfproto.ModelVersionStatus{
-			{
-				Version: 101,
-				State:   tfproto.ModelVersionStatus_END,
-			},
-			{
-				Version: 301,
-				State:   tfproto.ModelVersionStatus_AVAILABLE,
-			},
-		},
-	}
-	retval := checkServableResponse(request, 101)
-	assert.Equal(t, 34, retval)
-	retval = checkServableResponse(request, 301)
-	assert.Equal(t, 0, retval)
-
-	request = &tfproto.GetModelStatusResponse{
-		ModelVersionStatus: []*tfproto.ModelVersionStatus{
-			{
-				Version: 301,
-				State:   tfproto.ModelVersionStatus_AVAILABLE,
-			},
-			{
-				Version: 101,
-				State:   tfproto.ModelVersionStatus_END,
-			},
-		},
-	}
-	retval = checkServableResponse(request, 101)
-	assert.Equal(t, 34, retval)
-	retval = checkServableResponse(request, 301)
-	assert.Equal(t, 0, retval)
+func TestMainJSONOutputExitCodeMap(t *testing.T) {
+	server := tfstest.NewServer(tfstest.Model{Name: "resnet", Versions: []tfstest.Version{{Version: 1, Timeline: []tfstest.Step{{State: tfproto.ModelVersionStatus_LOADING}}}}})
+	defer server.Close()
 
+	out, code := runMainOutput(t, "-addr="+server.Addr, "-model-name=resnet", "-output=json", "-exit-code-map=state_loading=0")
+	assert.Equal(t, 0, code)
+	var report jsonReport
+	assert.Nil(t, json.Unmarshal([]byte(out), &report))
+	assert.Equal(t, 32, report.ExitCode)
+	assert.Equal(t, 0, *report.ProcessExitCode)
 }
@@ -0,0 +1,224 @@
+//
+// Copyright 2020 Cody Collier <cody@telnet.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/yaml.v3"
+
+	"github.com/codycollier/tfs-model-status-probe/probe"
+	"github.com/codycollier/tfs-model-status-probe/tfproto/tfproto"
+	"github.com/codycollier/tfs-model-status-probe/tfstest"
+)
+
+// scenario describes the models served by mock-server. Times are relative
+// to the server start.
+type scenario struct {
+	Models []scenarioModel `yaml:"models"`
+}
+
+type scenarioModel struct {
+	Name     string            `yaml:"name"`
+	Labels   map[string]int64  `yaml:"labels"`
+	Latency  time.Duration     `yaml:"latency"`
+	Hang     bool              `yaml:"hang"`
+	Error    *scenarioError    `yaml:"error"`
+	Versions []scenarioVersion `yaml:"versions"`
+}
+
+// scenarioError is a grpc error returned instead of the model status
+type scenarioError struct {
+	Code    string `yaml:"code"`
+	Message string `yaml:"message"`
+}
+
+// scenarioVersion has either a fixed state or a timeline of states
+type scenarioVersion struct {
+	Version  int64          `yaml:"version"`
+	State    string         `yaml:"state"`
+	Timeline []scenarioStep `yaml:"timeline"`
+}
+
+type scenarioStep struct {
+	After        time.Duration `yaml:"after"`
+	State        string        `yaml:"state"`
+	ErrorCode    string        `yaml:"error_code"`
+	ErrorMessage string        `yaml:"error_message"`
+}
+
+// Read a yaml or json scenario file into the models to serve
+func readScenario(path string) ([]tfstest.Model, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var s scenario
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&s); err != nil {
+		return nil, err
+	}
+
+	var models []tfstest.Model
+	seen := make(map[string]bool)
+	for _, m := range s.Models {
+		if m.Name == "" {
+			return nil, errors.New("model without a name")
+		}
+		if seen[m.Name] {
+			return nil, fmt.Errorf("model %v: duplicate model", m.Name)
+		}
+		seen[m.Name] = true
+		model := tfstest.Model{Name: m.Name, Labels: m.Labels, Latency: m.Latency, Hang: m.Hang}
+		if m.Error != nil {
+			code, ok := tfproto.Code_value[m.Error.Code]
+			if !ok || code == 0 {
+				return nil, fmt.Errorf("model %v: unknown error code: %v", m.Name, m.Error.Code)
+			}
+			model.Err = status.Error(codes.Code(code), m.Error.Message)
+		}
+		for _, v := range m.Versions {
+			version, err := v.toVersion()
+			if err != nil {
+				return nil, fmt.Errorf("model %v: %v", m.Name, err)
+			}
+			model.Versions = append(model.Versions, version)
+		}
+		models = append(models, model)
+	}
+	return models, nil
+}
+
+// Convert a scenario version, with a fixed state as a single step
+func (v scenarioVersion) toVersion() (tfstest.Version, error) {
+	if v.Version <= 0 {
+		return tfstest.Version{}, fmt.Errorf("invalid version: %v", v.Version)
+	}
+	steps := v.Timeline
+	if v.State != "" {
+		if len(steps) > 0 {
+			return tfstest.Version{}, fmt.Errorf("version %v: state and timeline are mutually exclusive", v.Version)
+		}
+		steps = []scenarioStep{{State: v.State}}
+	}
+	version := tfstest.Version{Version: v.Version}
+	for _, step := range steps {
+		state, ok := tfproto.ModelVersionStatus_State_value[step.State]
+		if !ok {
+			return tfstest.Version{}, fmt.Errorf("version %v: unknown state: %v", v.Version, step.State)
+		}
+		errorCode := int32(0)
+		if step.ErrorCode != "" {
+			errorCode, ok = tfproto.Code_value[step.ErrorCode]
+			if !ok {
+				return tfstest.Version{}, fmt.Errorf("version %v: unknown error code: %v", v.Version, step.ErrorCode)
+			}
+		}
+		version.Timeline = append(version.Timeline, tfstest.Step{
+			After:        step.After,
+			State:        tfproto.ModelVersionStatus_State(state),
+			ErrorCode:    tfproto.Code(errorCode),
+			ErrorMessage: step.ErrorMessage,
+		})
+	}
+	return version, nil
+}
+
+// Run the mock-server subcommand until interrupted, returning the exit code
+func runMockServer(args []string) int {
+	fs := flag.NewFlagSet("mock-server", flag.ExitOnError)
+	scenarioFile := fs.String("scenario", "", "The yaml or json scenario file describing the models to serve")
+	grpcListen := fs.String("grpc-listen", ":8500", "The host:port to serve the grpc api on (empty to disable)")
+	restListen := fs.String("rest-listen", ":8501", "The host:port to serve the REST api on (empty to disable)")
+	fs.Parse(args)
+
+	if *scenarioFile == "" {
+		log.Printf("Error: the -scenario option is required\n")
+		return probe.ExitInvalidConfig
+	}
+	models, err := readScenario(*scenarioFile)
+	if err != nil {
+		log.Printf("Error: reading scenario: %v\n", err)
+		return probe.ExitInvalidConfig
+	}
+	server := tfstest.New(models...)
+
+	// grpc and REST apis, serving the same models
+	var grpcServer *grpc.Server
+	var grpcListener net.Listener
+	if *grpcListen != "" {
+		grpcListener, err = net.Listen("tcp", *grpcListen)
+		if err != nil {
+			log.Printf("Error: %v\n", err)
+			return probe.ExitInvalidConfig
+		}
+		grpcServer = grpc.NewServer()
+		server.Register(grpcServer)
+	}
+	var restServer *http.Server
+	if *restListen != "" {
+		restServer = &http.Server{Addr: *restListen, Handler: server.RESTHandler()}
+	}
+	if grpcServer == nil && restServer == nil {
+		log.Printf("Error: both apis are disabled\n")
+		return probe.ExitInvalidConfig
+	}
+
+	errs := make(chan error, 2)
+	if grpcServer != nil {
+		log.Printf("Serving grpc on %v\n", *grpcListen)
+		go func() { errs <- grpcServer.Serve(grpcListener) }()
+	}
+	if restServer != nil {
+		log.Printf("Serving REST on %v\n", *restListen)
+		go func() { errs <- restServer.ListenAndServe() }()
+	}
+	log.Printf("Serving %v models from %v\n", len(models), *scenarioFile)
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	retval := probe.ExitAvailable
+	select {
+	case <-signals:
+	case err := <-errs:
+		log.Printf("Error serving: %v\n", err)
+		retval = probe.ExitInvalidConfig
+	}
+	if grpcServer != nil {
+		grpcServer.Stop()
+	}
+	if restServer != nil {
+		restServer.Shutdown(context.Background())
+	}
+	return retval
+}
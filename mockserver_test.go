@@ -0,0 +1,100 @@
+//
+// Copyright 2020 Cody Collier <cody@telnet.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/codycollier/tfs-model-status-probe/tfproto/tfproto"
+)
+
+const testScenario = `
+models:
+  - name: half_plus_two
+    labels: {stable: 1}
+    versions:
+      - version: 1
+        state: AVAILABLE
+      - version: 2
+        timeline:
+          - state: LOADING
+          - after: 30s
+            state: AVAILABLE
+          - after: 5m
+            state: END
+            error_code: NOT_FOUND
+            error_message: "model deleted"
+  - name: overloaded
+    latency: 250ms
+    error: {code: UNAVAILABLE, message: "too many requests"}
+  - name: stuck
+    hang: true
+`
+
+// Write a scenario file to a temp dir
+func writeScenario(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	assert.Nil(t, ioutil.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestReadScenario(t *testing.T) {
+	models, err := readScenario(writeScenario(t, "scenario.yaml", testScenario))
+	assert.Nil(t, err)
+	assert.Len(t, models, 3)
+
+	assert.Equal(t, "half_plus_two", models[0].Name)
+	assert.Equal(t, int64(1), models[0].Labels["stable"])
+	assert.Equal(t, tfproto.ModelVersionStatus_AVAILABLE, models[0].Versions[0].Timeline[0].State)
+	timeline := models[0].Versions[1].Timeline
+	assert.Len(t, timeline, 3)
+	assert.Equal(t, 30*time.Second, timeline[1].After)
+	assert.Equal(t, tfproto.Code_NOT_FOUND, timeline[2].ErrorCode)
+	assert.Equal(t, "model deleted", timeline[2].ErrorMessage)
+
+	assert.Equal(t, 250*time.Millisecond, models[1].Latency)
+	assert.Equal(t, codes.Unavailable, status.Code(models[1].Err))
+	assert.True(t, models[2].Hang)
+
+	// json is read as well
+	models, err = readScenario(writeScenario(t, "scenario.json",
+		`{"models": [{"name": "resnet", "versions": [{"version": 3, "timeline": [{"after": "1s", "state": "LOADING"}]}]}]}`))
+	assert.Nil(t, err)
+	assert.Equal(t, time.Second, models[0].Versions[0].Timeline[0].After)
+
+	invalid := []string{
+		`models: [{versions: [{version: 1, state: AVAILABLE}]}]`,
+		`models: [{name: a}, {name: a}]`,
+		`models: [{name: a, versions: [{version: 0, state: AVAILABLE}]}]`,
+		`models: [{name: a, versions: [{version: 1, state: READY}]}]`,
+		`models: [{name: a, versions: [{version: 1, state: AVAILABLE, timeline: [{state: LOADING}]}]}]`,
+		`models: [{name: a, error: {code: NOPE}}]`,
+		`models: [{name: a, versions: [{version: 1, timeline: [{state: END, error_code: NOPE}]}]}]`,
+		`models: [{name: a, statez: AVAILABLE}]`,
+	}
+	for _, content := range invalid {
+		_, err := readScenario(writeScenario(t, "scenario.yaml", content))
+		assert.NotNil(t, err, content)
+	}
+}
@@ -0,0 +1,127 @@
+//
+// Copyright 2020 Cody Collier <cody@telnet.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/codycollier/tfs-model-status-probe/probe"
+	"github.com/codycollier/tfs-model-status-probe/tfproto/tfproto"
+)
+
+// Status words of the nagios plugin exit codes
+var nagiosStatus = map[int]string{
+	nagiosOK:       "OK",
+	nagiosWarning:  "WARNING",
+	nagiosCritical: "CRITICAL",
+	nagiosUnknown:  "UNKNOWN",
+}
+
+// Escalate a mapped exit code when the rpc time crosses the warning or
+// critical threshold. Thresholds of 0 are disabled.
+func nagiosLatencyExitCode(exitCode int, rpcTime, warning, critical time.Duration) (int, string) {
+	switch {
+	case critical > 0 && rpcTime > critical && (exitCode == nagiosOK || exitCode == nagiosWarning):
+		return nagiosCritical, fmt.Sprintf("rpc time %v exceeds %v", rpcTime.Round(time.Millisecond), critical)
+	case warning > 0 && rpcTime > warning && exitCode == nagiosOK:
+		return nagiosWarning, fmt.Sprintf("rpc time %v exceeds %v", rpcTime.Round(time.Millisecond), warning)
+	}
+	return exitCode, ""
+}
+
+// Summarize the result of each model, as "name vN STATE"
+func nagiosSummary(result *probe.Result) string {
+	var parts []string
+	failureShown := false
+	for _, r := range result.Models {
+		if r.ExitCode == result.ExitCode {
+			failureShown = true
+		}
+		if r.Version == 0 {
+			parts = append(parts, fmt.Sprintf("%v: %v", r.Model, probe.ExitCodeText(r.ExitCode)))
+			continue
+		}
+		part := fmt.Sprintf("%v v%v %v", r.Model.Name, r.Version, r.State)
+		if r.ErrorCode != tfproto.Code_OK {
+			part += fmt.Sprintf(" (%v: %v)", r.ErrorCode, r.ErrorMessage)
+		}
+		// an AVAILABLE version can still fail the version constraints
+		if r.ExitCode != probe.ExitAvailable && r.State == tfproto.ModelVersionStatus_AVAILABLE {
+			part += ", " + probe.ExitCodeText(r.ExitCode)
+		}
+		parts = append(parts, part)
+	}
+
+	// connection failures, and failures of the signature check or smoke
+	// test, are not those of a model status
+	if result.ExitCode != probe.ExitAvailable && !failureShown {
+		var probeErr *probe.Error
+		switch {
+		case errors.As(result.Err, &probeErr):
+			parts = append(parts, result.Err.Error())
+		case result.Err != nil:
+			parts = append(parts, probe.ExitCodeText(result.ExitCode)+": "+result.Err.Error())
+		default:
+			parts = append(parts, probe.ExitCodeText(result.ExitCode))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Format a duration as nagios performance data, in seconds
+func nagiosPerfdata(label string, d, warning, critical time.Duration) string {
+	perfdata := fmt.Sprintf("%v=%.3fs", label, d.Seconds())
+	if warning > 0 || critical > 0 {
+		perfdata += ";" + nagiosThreshold(warning) + ";" + nagiosThreshold(critical)
+	}
+	return perfdata
+}
+
+func nagiosThreshold(d time.Duration) string {
+	if d <= 0 {
+		return ""
+	}
+	return strconv.FormatFloat(d.Seconds(), 'f', -1, 64)
+}
+
+// Write the single nagios plugin status line, with performance data, and
+// return the plugin exit code
+func writeNagiosOutput(w io.Writer, result *probe.Result, exitCodes exitCodeMap, warning, critical time.Duration) int {
+	exitCode := exitCodes.exitCode(result.ExitCode)
+	summary := nagiosSummary(result)
+	exitCode, latency := nagiosLatencyExitCode(exitCode, result.RPCLatency, warning, critical)
+	if latency != "" {
+		summary += ", " + latency
+	}
+
+	// the status line is a single line, and "|" starts the performance data
+	summary = strings.NewReplacer("\n", " ", "|", "/").Replace(summary)
+	status, ok := nagiosStatus[exitCode]
+	if !ok {
+		status = nagiosStatus[nagiosUnknown]
+	}
+	fmt.Fprintf(w, "TFS %v - %v | %v %v %v\n", status, summary,
+		nagiosPerfdata("rpc_time", result.RPCLatency, warning, critical),
+		nagiosPerfdata("dial_time", result.DialLatency, 0, 0),
+		nagiosPerfdata("time", result.Latency, 0, 0))
+	return exitCode
+}
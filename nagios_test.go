@@ -0,0 +1,102 @@
+//
+// Copyright 2020 Cody Collier <cody@telnet.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/codycollier/tfs-model-status-probe/probe"
+	"github.com/codycollier/tfs-model-status-probe/tfproto/tfproto"
+)
+
+func TestWriteNagiosOutput(t *testing.T) {
+	exitCodes := exitCodeProfiles["nagios"]
+	available := probe.ModelResult{
+		Model:   probe.Model{Name: "half_plus_two"},
+		Version: 123,
+		State:   tfproto.ModelVersionStatus_AVAILABLE,
+	}
+	result := &probe.Result{
+		Models:      []probe.ModelResult{available},
+		DialLatency: 3 * time.Millisecond,
+		RPCLatency:  12 * time.Millisecond,
+		Latency:     20 * time.Millisecond,
+	}
+
+	var out bytes.Buffer
+	assert.Equal(t, 0, writeNagiosOutput(&out, result, exitCodes, 500*time.Millisecond, time.Second))
+	assert.Equal(t, "TFS OK - half_plus_two v123 AVAILABLE | rpc_time=0.012s;0.5;1 dial_time=0.003s time=0.020s\n", out.String())
+
+	// the latency thresholds escalate
+	result.RPCLatency = 700 * time.Millisecond
+	out.Reset()
+	assert.Equal(t, 1, writeNagiosOutput(&out, result, exitCodes, 500*time.Millisecond, time.Second))
+	assert.Contains(t, out.String(), "TFS WARNING - half_plus_two v123 AVAILABLE, rpc time 700ms exceeds 500ms |")
+	result.RPCLatency = 1500 * time.Millisecond
+	out.Reset()
+	assert.Equal(t, 2, writeNagiosOutput(&out, result, exitCodes, 500*time.Millisecond, time.Second))
+	assert.Contains(t, out.String(), "TFS CRITICAL - ")
+	result.RPCLatency = 12 * time.Millisecond
+
+	// loading is a warning, a failed load or missing model critical
+	loading := probe.ModelResult{Model: probe.Model{Name: "resnet"}, Version: 2, State: tfproto.ModelVersionStatus_LOADING, ExitCode: 32}
+	result.Models = []probe.ModelResult{available, loading}
+	result.ExitCode = 32
+	out.Reset()
+	assert.Equal(t, 1, writeNagiosOutput(&out, result, exitCodes, 0, 0))
+	assert.Equal(t, "TFS WARNING - half_plus_two v123 AVAILABLE, resnet v2 LOADING | rpc_time=0.012s dial_time=0.003s time=0.020s\n", out.String())
+
+	failed := probe.ModelResult{
+		Model:        probe.Model{Name: "resnet"},
+		Version:      2,
+		State:        tfproto.ModelVersionStatus_END,
+		ErrorCode:    tfproto.Code_INVALID_ARGUMENT,
+		ErrorMessage: "Invalid SavedModel | bad graph",
+		ExitCode:     63,
+	}
+	result.Models = []probe.ModelResult{failed}
+	result.ExitCode = 63
+	out.Reset()
+	assert.Equal(t, 2, writeNagiosOutput(&out, result, exitCodes, 0, 0))
+	assert.Contains(t, out.String(), "TFS CRITICAL - resnet v2 END (INVALID_ARGUMENT: Invalid SavedModel / bad graph) |")
+
+	// a failed version constraint gives the reason
+	stale := available
+	stale.ExitCode = 25
+	result.Models = []probe.ModelResult{stale}
+	result.ExitCode = 25
+	out.Reset()
+	assert.Equal(t, 2, writeNagiosOutput(&out, result, exitCodes, 0, 0))
+	assert.Contains(t, out.String(), "TFS CRITICAL - half_plus_two v123 AVAILABLE, version older than the maximum age |")
+
+	result.Models = []probe.ModelResult{{Model: probe.Model{Name: "missing"}, ExitCode: 10}}
+	result.ExitCode = 10
+	out.Reset()
+	assert.Equal(t, 2, writeNagiosOutput(&out, result, exitCodes, 0, 0))
+	assert.Contains(t, out.String(), "TFS CRITICAL - missing: model not found |")
+
+	// connection problems are unknown
+	result = &probe.Result{ExitCode: 2, Err: errors.New("context deadline exceeded")}
+	out.Reset()
+	assert.Equal(t, 3, writeNagiosOutput(&out, result, exitCodes, 0, 0))
+	assert.Contains(t, out.String(), "TFS UNKNOWN - failed to connect: context deadline exceeded |")
+}
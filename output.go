@@ -0,0 +1,138 @@
+//
+// Copyright 2020 Cody Collier <cody@telnet.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/codycollier/tfs-model-status-probe/probe"
+)
+
+// jsonReport is the document written by -output=json
+type jsonReport struct {
+	Address         string              `json:"address"`
+	Protocol        string              `json:"protocol"`
+	Models          []jsonModel         `json:"models"`
+	Decision        string              `json:"decision"`
+	ExitCode        int                 `json:"exit_code"`
+	Category        string              `json:"category,omitempty"`
+	ProcessExitCode *int                `json:"process_exit_code,omitempty"` // after any exit code mapping, for the process report
+	Error           string              `json:"error,omitempty"`
+	Timing          jsonTiming          `json:"timing"`
+	SignatureDiffs  []jsonSignatureDiff `json:"signature_diffs,omitempty"`
+}
+
+type jsonModel struct {
+	Model            string              `json:"model"`
+	RequestedVersion int64               `json:"requested_version,omitempty"`
+	RequestedLabel   string              `json:"requested_label,omitempty"`
+	Versions         []jsonVersionStatus `json:"versions"`
+	SelectedVersion  int64               `json:"selected_version,omitempty"`
+	State            string              `json:"state,omitempty"`
+	ExitCode         int                 `json:"exit_code"`
+	Error            string              `json:"error,omitempty"`
+}
+
+type jsonVersionStatus struct {
+	Version      int64  `json:"version"`
+	State        string `json:"state"`
+	ErrorCode    string `json:"error_code"`
+	ErrorMessage string `json:"error_message,omitempty"`
+}
+
+type jsonSignatureDiff struct {
+	Signature  string `json:"signature"`
+	Kind       string `json:"kind"`
+	Key        string `json:"key,omitempty"`
+	Change     string `json:"change"`
+	Expected   string `json:"expected,omitempty"`
+	Actual     string `json:"actual,omitempty"`
+	Compatible bool   `json:"compatible"`
+}
+
+// Phase latencies in milliseconds
+type jsonTiming struct {
+	DialMs  float64 `json:"dial_ms"`
+	RPCMs   float64 `json:"rpc_ms"`
+	TotalMs float64 `json:"total_ms"`
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// Build the json report for a check result
+func newJSONReport(config probe.Config, result *probe.Result) *jsonReport {
+	report := &jsonReport{
+		Address:  config.Addr,
+		Protocol: config.Protocol,
+		Models:   []jsonModel{},
+		Decision: probe.ExitCodeText(result.ExitCode),
+		ExitCode: result.ExitCode,
+		Category: string(result.Category),
+		Timing: jsonTiming{
+			DialMs:  milliseconds(result.DialLatency),
+			RPCMs:   milliseconds(result.RPCLatency),
+			TotalMs: milliseconds(result.Latency),
+		},
+	}
+	if result.Err != nil {
+		report.Error = result.Err.Error()
+	}
+
+	for _, r := range result.Models {
+		model := jsonModel{
+			Model:            r.Model.Name,
+			RequestedVersion: r.Model.Version,
+			RequestedLabel:   r.Model.Label,
+			Versions:         []jsonVersionStatus{},
+			ExitCode:         r.ExitCode,
+		}
+		if r.Err != nil {
+			model.Error = r.Err.Error()
+		}
+		for _, res := range r.Response.GetModelVersionStatus() {
+			model.Versions = append(model.Versions, jsonVersionStatus{
+				Version:      res.Version,
+				State:        res.State.String(),
+				ErrorCode:    res.GetStatus().GetErrorCode().String(),
+				ErrorMessage: res.GetStatus().GetErrorMessage(),
+			})
+		}
+		if r.Version != 0 {
+			model.SelectedVersion = r.Version
+			model.State = r.State.String()
+		}
+		report.Models = append(report.Models, model)
+	}
+	for _, d := range result.SignatureDiffs {
+		report.SignatureDiffs = append(report.SignatureDiffs, jsonSignatureDiff(d))
+	}
+	return report
+}
+
+// Write the json report for a check result, with the mapped exit code of
+// the process
+func writeJSONReport(w io.Writer, config probe.Config, result *probe.Result, processExitCode int) error {
+	report := newJSONReport(config, result)
+	report.ProcessExitCode = &processExitCode
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
@@ -0,0 +1,114 @@
+//
+// Copyright 2020 Cody Collier <cody@telnet.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/codycollier/tfs-model-status-probe/probe"
+	"github.com/codycollier/tfs-model-status-probe/tfproto/tfproto"
+)
+
+func TestWriteJSONReport(t *testing.T) {
+	config := probe.Config{Addr: "localhost:8500", Protocol: "grpc"}
+	result := &probe.Result{
+		ExitCode: 32,
+		Category: probe.CategoryNotReady,
+		Models: []probe.ModelResult{
+			{
+				Model: probe.Model{Name: "half_plus_two", Version: 124},
+				Response: &tfproto.GetModelStatusResponse{
+					ModelVersionStatus: []*tfproto.ModelVersionStatus{
+						{Version: 124, State: tfproto.ModelVersionStatus_LOADING},
+						{Version: 123, State: tfproto.ModelVersionStatus_END, Status: &tfproto.StatusProto{ErrorCode: tfproto.Code_NOT_FOUND, ErrorMessage: "missing assets"}},
+					},
+				},
+				Version:  124,
+				State:    tfproto.ModelVersionStatus_LOADING,
+				ExitCode: 32,
+			},
+			{
+				Model:    probe.Model{Name: "resnet", Label: "stable"},
+				Err:      status.Error(codes.NotFound, "Could not find any versions of model resnet"),
+				ExitCode: 10,
+			},
+		},
+		Err:         &probe.Error{ExitCode: 32, Category: probe.CategoryNotReady, Model: "half_plus_two:124"},
+		Latency:     time.Millisecond * 5,
+		DialLatency: time.Millisecond * 2,
+		RPCLatency:  time.Millisecond * 3,
+	}
+
+	var buf bytes.Buffer
+	assert.Nil(t, writeJSONReport(&buf, config, result, 0))
+	var report map[string]interface{}
+	assert.Nil(t, json.Unmarshal(buf.Bytes(), &report))
+	assert.Equal(t, "localhost:8500", report["address"])
+	assert.Equal(t, "servable state is LOADING", report["decision"])
+	assert.Equal(t, float64(32), report["exit_code"])
+	assert.Equal(t, float64(0), report["process_exit_code"])
+	assert.Equal(t, "not_ready", report["category"])
+	assert.Equal(t, "model half_plus_two:124: servable state is LOADING", report["error"])
+	assert.Equal(t, map[string]interface{}{"dial_ms": float64(2), "rpc_ms": float64(3), "total_ms": float64(5)}, report["timing"])
+
+	models := report["models"].([]interface{})
+	assert.Len(t, models, 2)
+	model := models[0].(map[string]interface{})
+	assert.Equal(t, "half_plus_two", model["model"])
+	assert.Equal(t, float64(124), model["requested_version"])
+	assert.Equal(t, float64(124), model["selected_version"])
+	assert.Equal(t, "LOADING", model["state"])
+	versions := model["versions"].([]interface{})
+	assert.Equal(t, map[string]interface{}{"version": float64(123), "state": "END", "error_code": "NOT_FOUND", "error_message": "missing assets"}, versions[1])
+
+	model = models[1].(map[string]interface{})
+	assert.Equal(t, "stable", model["requested_label"])
+	assert.Equal(t, []interface{}{}, model["versions"])
+	assert.Nil(t, model["selected_version"])
+	assert.Contains(t, model["error"], "Could not find any versions")
+}
+
+func TestWriteJSONReportSignatureDiffs(t *testing.T) {
+	config := probe.Config{Addr: "localhost:8500", Protocol: "grpc"}
+	result := &probe.Result{
+		ExitCode: 50,
+		Category: probe.CategorySignature,
+		SignatureDiffs: []probe.SignatureDiff{
+			{Signature: "serving_default", Kind: "input", Key: "x", Change: "dtype", Expected: "DT_FLOAT", Actual: "DT_DOUBLE"},
+			{Signature: "regress_x_to_y", Kind: "signature", Change: "extra", Compatible: true},
+		},
+	}
+
+	var buf bytes.Buffer
+	assert.Nil(t, writeJSONReport(&buf, config, result, 50))
+	var report map[string]interface{}
+	assert.Nil(t, json.Unmarshal(buf.Bytes(), &report))
+	diffs := report["signature_diffs"].([]interface{})
+	assert.Len(t, diffs, 2)
+	assert.Equal(t, map[string]interface{}{
+		"signature": "serving_default", "kind": "input", "key": "x", "change": "dtype",
+		"expected": "DT_FLOAT", "actual": "DT_DOUBLE", "compatible": false,
+	}, diffs[0])
+	assert.Equal(t, map[string]interface{}{"signature": "regress_x_to_y", "kind": "signature", "change": "extra", "compatible": true}, diffs[1])
+}
@@ -0,0 +1,107 @@
+//
+// Copyright 2020 Cody Collier <cody@telnet.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package probe
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/codycollier/tfs-model-status-probe/tfproto/tfproto"
+)
+
+// Check the version constraints of the config against a response with an
+// AVAILABLE version. The constrained version is the requested version, or
+// else the highest AVAILABLE version.
+func (p *Prober) checkVersionConstraints(response *tfproto.GetModelStatusResponse, modelVersion int64) int {
+	available := 0
+	highest, highestAvailable := int64(0), int64(0)
+	for _, res := range response.ModelVersionStatus {
+		if res.Version > highest {
+			highest = res.Version
+		}
+		if res.State == tfproto.ModelVersionStatus_AVAILABLE {
+			available++
+			if res.Version > highestAvailable {
+				highestAvailable = res.Version
+			}
+		}
+	}
+	version := modelVersion
+	if version == 0 {
+		version = highestAvailable
+	}
+
+	if p.config.MinVersion != 0 && version < p.config.MinVersion {
+		p.log.Printf("Version %v is below the minimum version %v\n", version, p.config.MinVersion)
+		return ExitVersionTooOld
+	}
+	if p.config.MaxVersion != 0 && version > p.config.MaxVersion {
+		p.log.Printf("Version %v is above the maximum version %v\n", version, p.config.MaxVersion)
+		return ExitVersionTooNew
+	}
+	if p.config.ExpectLatestAvailable && highestAvailable != highest {
+		p.log.Printf("Latest version %v is not AVAILABLE, version %v is\n", highest, highestAvailable)
+		return ExitLatestNotAvailable
+	}
+	if p.config.MaxAvailableVersions != 0 && available > p.config.MaxAvailableVersions {
+		p.log.Printf("%v versions are AVAILABLE, at most %v expected\n", available, p.config.MaxAvailableVersions)
+		return ExitTooManyAvailable
+	}
+	if p.config.MaxVersionAge != 0 {
+		created, err := versionTime(version, p.config.VersionTimeLayout)
+		if err != nil {
+			p.log.Printf("Version %v is not a timestamp: %v\n", version, err)
+			return ExitVersionTimeInvalid
+		}
+		if age := timeNow().Sub(created); age > p.config.MaxVersionAge {
+			p.log.Printf("Version %v is %v old, older than %v\n", version, age.Round(time.Second), p.config.MaxVersionAge)
+			return ExitVersionStale
+		}
+	}
+	return 0
+}
+
+// The current time, replaced in tests
+var timeNow = time.Now
+
+// Translation of the version time layout fields to a go time layout
+var versionTimeFields = strings.NewReplacer("YYYY", "2006", "MM", "01", "DD", "02", "hh", "15", "mm", "04", "ss", "05")
+
+// Report whether a version time layout is made only of the layout fields
+func isVersionTimeLayout(layout string) bool {
+	return strings.Trim(versionTimeFields.Replace(layout), "0123456789") == "" && strings.Contains(layout, "YYYY")
+}
+
+// The earliest plausible version time in unix seconds, which rules out
+// plain version numbers
+var minUnixVersionTime = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Read a version as a timestamp, unix seconds when there is no layout. Unix
+// seconds before 2000, or more than a day ahead, such as milliseconds, are
+// not taken for a timestamp.
+func versionTime(version int64, layout string) (time.Time, error) {
+	if layout == "" {
+		t := time.Unix(version, 0)
+		if t.Before(minUnixVersionTime) || t.After(timeNow().Add(24*time.Hour)) {
+			return t, fmt.Errorf("%v is not a plausible time in unix seconds", version)
+		}
+		return t, nil
+	}
+	return time.Parse(versionTimeFields.Replace(layout), strconv.FormatInt(version, 10))
+}
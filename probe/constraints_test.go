@@ -0,0 +1,104 @@
+//
+// Copyright 2020 Cody Collier <cody@telnet.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package probe
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/codycollier/tfs-model-status-probe/tfproto/tfproto"
+)
+
+func TestVersionConstraints(t *testing.T) {
+	response := &tfproto.GetModelStatusResponse{
+		ModelVersionStatus: []*tfproto.ModelVersionStatus{
+			{Version: 3, State: tfproto.ModelVersionStatus_LOADING},
+			{Version: 1, State: tfproto.ModelVersionStatus_AVAILABLE},
+			{Version: 2, State: tfproto.ModelVersionStatus_AVAILABLE},
+		},
+	}
+	check := func(config Config, modelVersion int64) int {
+		p := testProber()
+		p.config = config
+		return p.checkServableResponse(response, modelVersion)
+	}
+
+	assert.Equal(t, 0, check(Config{}, 0))
+
+	// the highest AVAILABLE version, or the requested version
+	assert.Equal(t, 0, check(Config{MinVersion: 2}, 0))
+	assert.Equal(t, 21, check(Config{MinVersion: 3}, 0))
+	assert.Equal(t, 21, check(Config{MinVersion: 2}, 1))
+	assert.Equal(t, 0, check(Config{MaxVersion: 2}, 0))
+	assert.Equal(t, 22, check(Config{MaxVersion: 1}, 0))
+	assert.Equal(t, 0, check(Config{MaxVersion: 1}, 1))
+
+	// the highest version present is still loading
+	assert.Equal(t, 23, check(Config{ExpectLatestAvailable: true}, 0))
+	assert.Equal(t, 24, check(Config{MaxAvailableVersions: 1}, 0))
+	assert.Equal(t, 0, check(Config{MaxAvailableVersions: 2}, 0))
+
+	// constraints only apply once AVAILABLE
+	assert.Equal(t, 32, check(Config{MinVersion: 5}, 3))
+
+	response.ModelVersionStatus[0].State = tfproto.ModelVersionStatus_AVAILABLE
+	assert.Equal(t, 0, check(Config{ExpectLatestAvailable: true, MinVersion: 3}, 0))
+	assert.Equal(t, CategoryConstraint, CategoryOf(23))
+}
+
+func TestVersionAge(t *testing.T) {
+	defer func(now func() time.Time) { timeNow = now }(timeNow)
+	timeNow = func() time.Time { return time.Date(2020, 11, 20, 12, 0, 0, 0, time.UTC) }
+
+	response := func(version int64) *tfproto.GetModelStatusResponse {
+		return &tfproto.GetModelStatusResponse{ModelVersionStatus: []*tfproto.ModelVersionStatus{
+			{Version: version, State: tfproto.ModelVersionStatus_AVAILABLE},
+		}}
+	}
+	check := func(config Config, version int64) int {
+		p := testProber()
+		p.config = config
+		return p.checkServableResponse(response(version), 0)
+	}
+
+	// unix seconds, 2020-11-20 10:00 and 2020-11-18 12:00
+	day := Config{MaxVersionAge: 24 * time.Hour}
+	assert.Equal(t, 0, check(day, 1605866400))
+	assert.Equal(t, 25, check(day, 1605700800))
+	assert.Equal(t, 0, check(Config{}, 1605700800))
+
+	// a timestamped layout
+	layout := Config{MaxVersionAge: 24 * time.Hour, VersionTimeLayout: "YYYYMMDDhhmm"}
+	assert.Equal(t, 0, check(layout, 202011201000))
+	assert.Equal(t, 25, check(layout, 202011181200))
+	assert.Equal(t, 28, check(layout, 7))
+
+	// plain version numbers and milliseconds are not unix seconds
+	assert.Equal(t, 28, check(day, 7))
+	assert.Equal(t, 28, check(day, 1605866400000))
+
+	_, err := New(Config{VersionTimeLayout: "YYYY-MM-DD"})
+	assert.NotNil(t, err)
+	_, err = New(Config{VersionTimeLayout: "hhmm"})
+	assert.NotNil(t, err)
+	_, err = New(Config{MaxVersionAge: -time.Hour})
+	assert.NotNil(t, err)
+	_, err = New(Config{VersionTimeLayout: "YYYYMMDD"})
+	assert.Nil(t, err)
+}
@@ -0,0 +1,262 @@
+//
+// Copyright 2020 Cody Collier <cody@telnet.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package probe
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/codycollier/tfs-model-status-probe/tfproto/tfproto"
+)
+
+// Exit codes of the command line probe. Each check outcome maps to one of
+// these, and the mapping is kept stable for scripts and exec probes.
+const (
+	ExitAvailable             = 0
+	ExitInvalidConfig         = 1
+	ExitDialFailed            = 2
+	ExitRPCFailed             = 3
+	ExitTLSHandshakeFailed    = 4
+	ExitTLSCertificateInvalid = 5
+	ExitModelNotFound         = 10
+	ExitEmptyResponse         = 11
+	ExitVersionNotFound       = 12
+	ExitVersionLabelInvalid   = 13
+	ExitWaitDeadline          = 14
+	ExitVersionTooOld         = 21
+	ExitVersionTooNew         = 22
+	ExitLatestNotAvailable    = 23
+	ExitTooManyAvailable      = 24
+	ExitVersionStale          = 25
+	ExitFleetDivergent        = 26
+	ExitFleetQuorum           = 27
+	ExitVersionTimeInvalid    = 28
+	ExitStateUnknown          = 30
+	ExitStateStart            = 31
+	ExitStateLoading          = 32
+	ExitStateUnloading        = 33
+	ExitStateEnd              = 34
+	ExitPredictFailed         = 40
+	ExitPredictOutputMissing  = 41
+	ExitPredictDtypeMismatch  = 42
+	ExitPredictShapeMismatch  = 43
+	ExitPredictValuesMismatch = 44
+	ExitSignatureIncompatible = 50
+	ExitMetadataFailed        = 51
+	ExitLoadFailed            = 60 // plus the tfproto.Code of the failure, 61 to 76
+	ExitUnexpectedState       = 100
+)
+
+// LoadFailedExitCode maps the error code of a version which ended with an
+// error, such as a failed load, to an exit code in the ExitLoadFailed family
+func LoadFailedExitCode(code tfproto.Code) int {
+	if code <= tfproto.Code_OK || code > tfproto.Code_UNAUTHENTICATED {
+		code = tfproto.Code_UNKNOWN
+	}
+	return ExitLoadFailed + int(code)
+}
+
+// Short descriptions of the exit codes, as listed in the README
+var exitCodeText = map[int]string{
+	ExitAvailable:             "servable state is AVAILABLE",
+	ExitInvalidConfig:         "invalid options",
+	ExitDialFailed:            "failed to connect",
+	ExitRPCFailed:             "model status call failed",
+	ExitTLSHandshakeFailed:    "tls handshake failed",
+	ExitTLSCertificateInvalid: "tls certificate validation failed",
+	ExitModelNotFound:         "model not found",
+	ExitEmptyResponse:         "empty response",
+	ExitVersionNotFound:       "version not found",
+	ExitVersionLabelInvalid:   "version label not found or not resolved",
+	ExitWaitDeadline:          "gave up waiting at the deadline",
+	ExitVersionTooOld:         "version below the minimum",
+	ExitVersionTooNew:         "version above the maximum",
+	ExitLatestNotAvailable:    "latest version not AVAILABLE",
+	ExitTooManyAvailable:      "too many versions AVAILABLE",
+	ExitVersionStale:          "version older than the maximum age",
+	ExitFleetDivergent:        "replicas serve different versions",
+	ExitFleetQuorum:           "too few replicas AVAILABLE",
+	ExitVersionTimeInvalid:    "version is not a timestamp",
+	ExitStateUnknown:          "servable state is UNKNOWN",
+	ExitStateStart:            "servable state is START",
+	ExitStateLoading:          "servable state is LOADING",
+	ExitStateUnloading:        "servable state is UNLOADING",
+	ExitStateEnd:              "servable state is END",
+	ExitPredictFailed:         "smoke test predict call failed",
+	ExitPredictOutputMissing:  "smoke test output missing",
+	ExitPredictDtypeMismatch:  "smoke test output dtype mismatch",
+	ExitPredictShapeMismatch:  "smoke test output shape mismatch",
+	ExitPredictValuesMismatch: "smoke test output values outside tolerance",
+	ExitSignatureIncompatible: "incompatible signature change",
+	ExitMetadataFailed:        "model metadata call failed",
+	ExitUnexpectedState:       "unexpected servable state",
+}
+
+// ExitCodeText returns a short description of an exit code
+func ExitCodeText(code int) string {
+	if text, ok := exitCodeText[code]; ok {
+		return text
+	}
+	if code > ExitLoadFailed && code <= ExitLoadFailed+int(tfproto.Code_UNAUTHENTICATED) {
+		return fmt.Sprintf("servable state is END with error %v", tfproto.Code(code-ExitLoadFailed))
+	}
+	return fmt.Sprintf("exit code %d", code)
+}
+
+// Outcome names of the exit codes, stable for exit code mappings
+var exitCodeOutcome = map[int]string{
+	ExitAvailable:             "available",
+	ExitInvalidConfig:         "invalid_config",
+	ExitDialFailed:            "dial",
+	ExitRPCFailed:             "rpc",
+	ExitTLSHandshakeFailed:    "tls_handshake",
+	ExitTLSCertificateInvalid: "tls_certificate",
+	ExitModelNotFound:         "model_not_found",
+	ExitEmptyResponse:         "empty_response",
+	ExitVersionNotFound:       "version_not_found",
+	ExitVersionLabelInvalid:   "version_label",
+	ExitWaitDeadline:          "wait_deadline",
+	ExitVersionTooOld:         "version_too_old",
+	ExitVersionTooNew:         "version_too_new",
+	ExitLatestNotAvailable:    "latest_not_available",
+	ExitTooManyAvailable:      "too_many_available",
+	ExitVersionStale:          "version_stale",
+	ExitFleetDivergent:        "fleet_divergent",
+	ExitFleetQuorum:           "fleet_quorum",
+	ExitVersionTimeInvalid:    "version_time_invalid",
+	ExitStateUnknown:          "state_unknown",
+	ExitStateStart:            "state_start",
+	ExitStateLoading:          "state_loading",
+	ExitStateUnloading:        "state_unloading",
+	ExitStateEnd:              "state_end",
+	ExitPredictFailed:         "predict_failed",
+	ExitPredictOutputMissing:  "predict_output_missing",
+	ExitPredictDtypeMismatch:  "predict_dtype_mismatch",
+	ExitPredictShapeMismatch:  "predict_shape_mismatch",
+	ExitPredictValuesMismatch: "predict_values_mismatch",
+	ExitSignatureIncompatible: "signature_incompatible",
+	ExitMetadataFailed:        "metadata_failed",
+	ExitUnexpectedState:       "unexpected_state",
+}
+
+// OutcomeOf returns the outcome name of an exit code. The load failures
+// share the "load_failed" outcome.
+func OutcomeOf(code int) string {
+	if outcome, ok := exitCodeOutcome[code]; ok {
+		return outcome
+	}
+	if CategoryOf(code) == CategoryLoadFailed {
+		return "load_failed"
+	}
+	return ""
+}
+
+// Outcomes returns every outcome name, in exit code order
+func Outcomes() []string {
+	codes := make([]int, 0, len(exitCodeOutcome))
+	for code := range exitCodeOutcome {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+	outcomes := make([]string, 0, len(codes)+1)
+	for _, code := range codes {
+		outcomes = append(outcomes, exitCodeOutcome[code])
+		if code == ExitMetadataFailed {
+			outcomes = append(outcomes, "load_failed")
+		}
+	}
+	return outcomes
+}
+
+// Category groups exit codes by the kind of failure
+type Category string
+
+const (
+	CategoryNone       Category = ""
+	CategoryConfig     Category = "config"
+	CategoryConnection Category = "connection"
+	CategoryRPC        Category = "rpc"
+	CategoryNotFound   Category = "not_found"
+	CategoryResponse   Category = "response"
+	CategoryTimeout    Category = "timeout"
+	CategoryNotReady   Category = "not_ready"
+	CategoryInference  Category = "inference"
+	CategorySignature  Category = "signature"
+	CategoryLoadFailed Category = "load_failed"
+	CategoryFleet      Category = "fleet"
+	CategoryConstraint Category = "constraint"
+)
+
+// CategoryOf returns the category of an exit code
+func CategoryOf(code int) Category {
+	switch {
+	case code == ExitAvailable:
+		return CategoryNone
+	case code == ExitInvalidConfig:
+		return CategoryConfig
+	case code == ExitDialFailed, code == ExitTLSHandshakeFailed, code == ExitTLSCertificateInvalid:
+		return CategoryConnection
+	case code == ExitRPCFailed:
+		return CategoryRPC
+	case code == ExitModelNotFound, code == ExitVersionNotFound, code == ExitVersionLabelInvalid:
+		return CategoryNotFound
+	case code == ExitEmptyResponse:
+		return CategoryResponse
+	case code == ExitWaitDeadline:
+		return CategoryTimeout
+	case code >= ExitVersionTooOld && code <= ExitVersionStale, code == ExitVersionTimeInvalid:
+		return CategoryConstraint
+	case code == ExitFleetDivergent, code == ExitFleetQuorum:
+		return CategoryFleet
+	case code >= ExitPredictFailed && code < ExitSignatureIncompatible:
+		return CategoryInference
+	case code >= ExitSignatureIncompatible && code < ExitLoadFailed:
+		return CategorySignature
+	case code > ExitLoadFailed && code < 80:
+		return CategoryLoadFailed
+	default:
+		return CategoryNotReady
+	}
+}
+
+// Error is a failed check. The underlying error, such as the rpc error, is
+// available through errors.Unwrap when there is one.
+type Error struct {
+	ExitCode int
+	Category Category
+	Model    string
+	Err      error
+}
+
+func newError(code int, model string, err error) *Error {
+	return &Error{ExitCode: code, Category: CategoryOf(code), Model: model, Err: err}
+}
+
+func (e *Error) Error() string {
+	msg := ExitCodeText(e.ExitCode)
+	if e.Model != "" {
+		msg = "model " + e.Model + ": " + msg
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,242 @@
+//
+// Copyright 2020 Cody Collier <cody@telnet.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package probe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/codycollier/tfs-model-status-probe/tfproto/tfproto"
+)
+
+// resolver looks up replica addresses, replaced in tests
+type resolver interface {
+	LookupHost(ctx context.Context, host string) ([]string, error)
+	LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error)
+}
+
+var replicaResolver resolver = net.DefaultResolver
+
+// ResolveReplicas expands a fleet target into replica addresses. The target
+// is a comma separated list of host:port, "dns:name:port" for every A or
+// AAAA record of the name, or "srv:name" for every SRV record of the name.
+func ResolveReplicas(ctx context.Context, target string) ([]string, error) {
+	var addrs []string
+	switch {
+	case strings.HasPrefix(target, "dns:"):
+		host, port, err := net.SplitHostPort(strings.TrimPrefix(target, "dns:"))
+		if err != nil {
+			return nil, err
+		}
+		ips, err := replicaResolver.LookupHost(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		for _, ip := range ips {
+			addrs = append(addrs, net.JoinHostPort(ip, port))
+		}
+	case strings.HasPrefix(target, "srv:"):
+		_, records, err := replicaResolver.LookupSRV(ctx, "", "", strings.TrimPrefix(target, "srv:"))
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range records {
+			addrs = append(addrs, net.JoinHostPort(strings.TrimSuffix(record.Target, "."), strconv.Itoa(int(record.Port))))
+		}
+	default:
+		for _, addr := range strings.Split(target, ",") {
+			if addr = strings.TrimSpace(addr); addr != "" {
+				addrs = append(addrs, addr)
+			}
+		}
+	}
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("no replicas found for %v", target)
+	}
+	sort.Strings(addrs)
+	return addrs, nil
+}
+
+// Fleet checks the same models on every replica of a service, for
+// consistency during rollouts. A model must be AVAILABLE on a quorum of
+// replicas, and those replicas must serve the same AVAILABLE versions.
+type Fleet struct {
+	probers []*Prober
+	config  Config
+	quorum  int
+}
+
+// ReplicaResult is the check of one replica
+type ReplicaResult struct {
+	Addr   string
+	Result *Result
+}
+
+// FleetModel summarizes a model across the replicas. Versions are the
+// AVAILABLE versions of each replica which has the model AVAILABLE.
+type FleetModel struct {
+	Model     Model
+	Available int
+	Versions  map[string][]int64
+	Divergent bool
+}
+
+// FleetResult is the outcome of a fleet Check. Replicas are in address
+// order, and Models in the order of the configured models.
+type FleetResult struct {
+	ExitCode int
+	Category Category
+	Err      error // an *Error when ExitCode is non-zero
+	Quorum   int
+	Replicas []ReplicaResult
+	Models   []FleetModel
+	Latency  time.Duration
+}
+
+// NewFleet validates the config and returns a Fleet over the addresses. The
+// config Addr is ignored, and waiting is not supported. The quorum defaults
+// to every replica.
+func NewFleet(config Config, addrs []string, quorum int) (*Fleet, error) {
+	if len(addrs) == 0 {
+		return nil, newError(ExitInvalidConfig, "", errors.New("no replicas"))
+	}
+	if quorum <= 0 {
+		quorum = len(addrs)
+	}
+	if quorum > len(addrs) {
+		return nil, newError(ExitInvalidConfig, "", fmt.Errorf("quorum (%v) exceeds the number of replicas (%v)", quorum, len(addrs)))
+	}
+	if config.Wait {
+		return nil, newError(ExitInvalidConfig, "", errors.New("waiting is not supported across a fleet"))
+	}
+	f := &Fleet{quorum: quorum}
+	for _, addr := range addrs {
+		replicaConfig := config
+		replicaConfig.Addr = addr
+		p, err := New(replicaConfig)
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		f.probers = append(f.probers, p)
+	}
+	f.config = f.probers[0].Config()
+	return f, nil
+}
+
+// Close the connection to every replica
+func (f *Fleet) Close() {
+	for _, p := range f.probers {
+		p.Close()
+	}
+}
+
+// Check every replica concurrently, then the quorum and version consistency
+// of each model
+func (f *Fleet) Check(ctx context.Context) *FleetResult {
+	start := time.Now()
+	result := &FleetResult{Quorum: f.quorum, Replicas: make([]ReplicaResult, len(f.probers))}
+	var wg sync.WaitGroup
+	for i, p := range f.probers {
+		wg.Add(1)
+		go func(i int, p *Prober) {
+			defer wg.Done()
+			result.Replicas[i] = ReplicaResult{Addr: p.Config().Addr, Result: p.Check(ctx)}
+		}(i, p)
+	}
+	wg.Wait()
+
+	var quorumErr, divergentErr *Error
+	for i, model := range f.config.Models {
+		summary := FleetModel{Model: model, Versions: map[string][]int64{}}
+		for _, replica := range result.Replicas {
+			if i >= len(replica.Result.Models) || replica.Result.Models[i].ExitCode != ExitAvailable {
+				continue
+			}
+			summary.Available++
+			summary.Versions[replica.Addr] = availableVersions(replica.Result.Models[i].Response)
+		}
+		summary.Divergent = isDivergent(summary.Versions)
+		result.Models = append(result.Models, summary)
+
+		if summary.Available < f.quorum && quorumErr == nil {
+			quorumErr = newError(ExitFleetQuorum, model.String(),
+				fmt.Errorf("%v of %v replicas AVAILABLE, quorum %v", summary.Available, len(result.Replicas), f.quorum))
+		}
+		if summary.Divergent && divergentErr == nil {
+			divergentErr = newError(ExitFleetDivergent, model.String(), fmt.Errorf("AVAILABLE versions %v", formatVersions(summary.Versions)))
+		}
+	}
+
+	// too few replicas is worse than replicas which disagree
+	switch {
+	case quorumErr != nil:
+		result.ExitCode, result.Err = ExitFleetQuorum, quorumErr
+	case divergentErr != nil:
+		result.ExitCode, result.Err = ExitFleetDivergent, divergentErr
+	}
+	result.Category = CategoryOf(result.ExitCode)
+	result.Latency = time.Since(start)
+	return result
+}
+
+// The AVAILABLE versions of a model status response, in order
+func availableVersions(response *tfproto.GetModelStatusResponse) []int64 {
+	var versions []int64
+	for _, res := range response.GetModelVersionStatus() {
+		if res.State == tfproto.ModelVersionStatus_AVAILABLE {
+			versions = append(versions, res.Version)
+		}
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i] < versions[j] })
+	return versions
+}
+
+// Report whether the replicas have different AVAILABLE versions
+func isDivergent(versions map[string][]int64) bool {
+	first := ""
+	for _, v := range versions {
+		key := fmt.Sprint(v)
+		if first == "" {
+			first = key
+		} else if key != first {
+			return true
+		}
+	}
+	return false
+}
+
+// Format the versions of each replica, in address order
+func formatVersions(versions map[string][]int64) string {
+	addrs := make([]string, 0, len(versions))
+	for addr := range versions {
+		addrs = append(addrs, addr)
+	}
+	sort.Strings(addrs)
+	parts := make([]string, len(addrs))
+	for i, addr := range addrs {
+		parts[i] = fmt.Sprintf("%v %v", addr, versions[addr])
+	}
+	return strings.Join(parts, ", ")
+}
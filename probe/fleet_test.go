@@ -0,0 +1,146 @@
+//
+// Copyright 2020 Cody Collier <cody@telnet.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package probe
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/codycollier/tfs-model-status-probe/tfproto/tfproto"
+	"github.com/codycollier/tfs-model-status-probe/tfstest"
+)
+
+// fakeResolver answers lookups from fixed records
+type fakeResolver struct {
+	hosts map[string][]string
+	srv   map[string][]*net.SRV
+}
+
+func (r *fakeResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	if addrs, ok := r.hosts[host]; ok {
+		return addrs, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+}
+
+func (r *fakeResolver) LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error) {
+	if records, ok := r.srv[name]; ok {
+		return name, records, nil
+	}
+	return "", nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+func TestResolveReplicas(t *testing.T) {
+	defer func(r resolver) { replicaResolver = r }(replicaResolver)
+	replicaResolver = &fakeResolver{
+		hosts: map[string][]string{"tfs.default.svc": {"10.0.0.2", "10.0.0.1", "fd00::1"}},
+		srv: map[string][]*net.SRV{"_grpc._tcp.tfs.default.svc": {
+			{Target: "tfs-1.tfs.default.svc.", Port: 8500},
+			{Target: "tfs-0.tfs.default.svc.", Port: 8500},
+		}},
+	}
+	ctx := context.Background()
+
+	addrs, err := ResolveReplicas(ctx, "b:8500, a:8500")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a:8500", "b:8500"}, addrs)
+
+	addrs, err = ResolveReplicas(ctx, "dns:tfs.default.svc:8500")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"10.0.0.1:8500", "10.0.0.2:8500", "[fd00::1]:8500"}, addrs)
+
+	addrs, err = ResolveReplicas(ctx, "srv:_grpc._tcp.tfs.default.svc")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"tfs-0.tfs.default.svc:8500", "tfs-1.tfs.default.svc:8500"}, addrs)
+
+	for _, target := range []string{"", "dns:tfs.default.svc", "dns:missing:8500", "srv:missing"} {
+		_, err = ResolveReplicas(ctx, target)
+		assert.NotNil(t, err, target)
+	}
+}
+
+// A fake replica serving half_plus_two with the versions AVAILABLE
+func replica(versions ...int64) *tfstest.Server {
+	model := tfstest.Model{Name: "half_plus_two"}
+	for _, version := range versions {
+		model.Versions = append(model.Versions, tfstest.Version{Version: version, Timeline: tfstest.Available()})
+	}
+	return tfstest.NewServer(model)
+}
+
+func checkFleet(t *testing.T, quorum int, replicas ...*tfstest.Server) *FleetResult {
+	var addrs []string
+	for _, r := range replicas {
+		addrs = append(addrs, r.Addr)
+	}
+	f, err := NewFleet(Config{Models: []Model{{Name: "half_plus_two"}}, ConnectTimeout: 300 * time.Millisecond}, addrs, quorum)
+	assert.Nil(t, err)
+	defer f.Close()
+	return f.Check(context.Background())
+}
+
+func TestFleet(t *testing.T) {
+	a, b, c := replica(1, 2), replica(1, 2), replica(1)
+	defer a.Close()
+	defer b.Close()
+	defer c.Close()
+	down := replica()
+	down.Close()
+	empty := replica()
+	defer empty.Close()
+
+	// converged
+	result := checkFleet(t, 0, a, b)
+	assert.Equal(t, ExitAvailable, result.ExitCode)
+	assert.Len(t, result.Replicas, 2)
+	assert.Equal(t, 2, result.Models[0].Available)
+	assert.Equal(t, []int64{1, 2}, result.Models[0].Versions[a.Addr])
+	assert.False(t, result.Models[0].Divergent)
+
+	// one replica still serves the old version
+	result = checkFleet(t, 0, a, b, c)
+	assert.Equal(t, ExitFleetDivergent, result.ExitCode)
+	assert.Equal(t, CategoryFleet, result.Category)
+	assert.True(t, result.Models[0].Divergent)
+	assert.Contains(t, result.Err.Error(), "replicas serve different versions")
+
+	// an unreachable replica, with and without a quorum
+	result = checkFleet(t, 0, a, b, down)
+	assert.Equal(t, ExitFleetQuorum, result.ExitCode)
+	assert.Contains(t, result.Err.Error(), "2 of 3 replicas AVAILABLE, quorum 3")
+	assert.Equal(t, ExitDialFailed, result.Replicas[2].Result.ExitCode)
+	result = checkFleet(t, 2, a, b, down)
+	assert.Equal(t, ExitAvailable, result.ExitCode)
+
+	// quorum takes precedence over divergence
+	result = checkFleet(t, 0, a, c, empty)
+	assert.Equal(t, ExitFleetQuorum, result.ExitCode)
+
+	_, err := NewFleet(Config{}, nil, 0)
+	assert.NotNil(t, err)
+	_, err = NewFleet(Config{}, []string{a.Addr}, 2)
+	assert.NotNil(t, err)
+	_, err = NewFleet(Config{Wait: true}, []string{a.Addr}, 0)
+	assert.NotNil(t, err)
+	_, err = NewFleet(Config{Protocol: "udp"}, []string{a.Addr}, 0)
+	assert.NotNil(t, err)
+	assert.Equal(t, tfproto.ModelVersionStatus_AVAILABLE, checkFleet(t, 1, a).Replicas[0].Result.State)
+}
@@ -0,0 +1,118 @@
+//
+// Copyright 2020 Cody Collier <cody@telnet.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package probe
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sort"
+
+	"google.golang.org/protobuf/encoding/prototext"
+
+	"github.com/codycollier/tfs-model-status-probe/tfproto/tfproto"
+)
+
+// ReadModelConfigFile reads the models from a TFS model config file
+// (ModelServerConfig in protobuf text format, as passed to
+// --model_config_file). Each model carries its version policy.
+func ReadModelConfigFile(path string) ([]Model, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	config := &tfproto.ModelServerConfig{}
+	if err := prototext.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("parsing model config: %v", err)
+	}
+	configList := config.GetModelConfigList()
+	if configList == nil {
+		return nil, errors.New("model config has no model_config_list")
+	}
+	if len(configList.Config) == 0 {
+		return nil, errors.New("model config has no models")
+	}
+
+	var targets []Model
+	for _, model := range configList.Config {
+		if model.Name == "" {
+			return nil, errors.New("model config entry without a name")
+		}
+		policy := model.ModelVersionPolicy
+		if policy == nil {
+			policy = &tfproto.FileSystemStoragePathSourceConfig_ServableVersionPolicy{}
+		}
+		targets = append(targets, Model{Name: model.Name, Policy: policy})
+	}
+	return targets, nil
+}
+
+// Parse the proto msg response and map to an appropriate return value, with
+// every version required by the version policy expected to be AVAILABLE. The
+// version checked is returned too: the first failing version, else the first
+// required version.
+func (p *Prober) checkVersionPolicy(response *tfproto.GetModelStatusResponse, policy *tfproto.FileSystemStoragePathSourceConfig_ServableVersionPolicy) (int, int64) {
+
+	// Ensure non-empty response
+	if len(response.ModelVersionStatus) == 0 {
+		p.log.Println("Empty response")
+		return ExitEmptyResponse, 0
+	}
+
+	// Work out which versions must be served
+	var versions []int64
+	switch {
+	case policy.GetSpecific() != nil:
+		versions = policy.GetSpecific().Versions
+	case policy.GetAll() != nil:
+		// versions which ended cleanly were removed from disk, not failed
+		for _, res := range response.ModelVersionStatus {
+			ended := res.State == tfproto.ModelVersionStatus_END && res.GetStatus().GetErrorCode() == tfproto.Code_OK
+			if !ended {
+				versions = append(versions, res.Version)
+			}
+		}
+	default:
+		// latest is the default policy, serving the highest N versions
+		numVersions := int(policy.GetLatest().GetNumVersions())
+		if numVersions == 0 {
+			numVersions = 1
+		}
+		for _, res := range response.ModelVersionStatus {
+			versions = append(versions, res.Version)
+		}
+		sort.Slice(versions, func(i, j int) bool { return versions[i] > versions[j] })
+		if len(versions) > numVersions {
+			versions = versions[:numVersions]
+		}
+	}
+
+	// No versions to serve? Nothing can be AVAILABLE.
+	if len(versions) == 0 {
+		p.log.Println("No versions found for the version policy")
+		return ExitVersionNotFound, 0
+	}
+
+	// Check each version, returning the first failure
+	for _, version := range versions {
+		p.log.Printf("Checking version: %v\n", version)
+		if retval := p.checkServableResponse(response, version); retval != 0 {
+			return retval, version
+		}
+	}
+	return 0, versions[0]
+}
@@ -0,0 +1,176 @@
+//
+// Copyright 2020 Cody Collier <cody@telnet.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package probe
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/codycollier/tfs-model-status-probe/tfproto/tfproto"
+)
+
+const testModelConfig = `
+model_config_list {
+  config {
+    name: 'half_plus_two'
+    base_path: '/models/half_plus_two'
+    model_platform: 'tensorflow'
+  }
+  config {
+    name: "half_plus_three"
+    base_path: "/models/half_plus_three"
+    model_platform: "tensorflow"
+    model_version_policy {
+      specific {
+        versions: 1
+        versions: 3
+      }
+    }
+  }
+  config {
+    name: "resnet"
+    base_path: "/models/resnet"
+    model_platform: "tensorflow"
+    model_version_policy {
+      latest {
+        num_versions: 2
+      }
+    }
+  }
+}
+`
+
+func TestReadModelConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "models.config")
+	assert.Nil(t, ioutil.WriteFile(path, []byte(testModelConfig), 0644))
+
+	targets, err := ReadModelConfigFile(path)
+	assert.Nil(t, err)
+	assert.Len(t, targets, 3)
+	assert.Equal(t, "half_plus_two", targets[0].Name)
+	assert.NotNil(t, targets[0].Policy)
+	assert.Equal(t, []int64{1, 3}, targets[1].Policy.GetSpecific().Versions)
+	assert.Equal(t, uint32(2), targets[2].Policy.GetLatest().NumVersions)
+
+	_, err = ReadModelConfigFile(filepath.Join(t.TempDir(), "missing.config"))
+	assert.NotNil(t, err)
+}
+
+func TestVersionPolicyLatest(t *testing.T) {
+	response := &tfproto.GetModelStatusResponse{
+		ModelVersionStatus: []*tfproto.ModelVersionStatus{
+			{Version: 1, State: tfproto.ModelVersionStatus_END},
+			{Version: 3, State: tfproto.ModelVersionStatus_LOADING},
+			{Version: 2, State: tfproto.ModelVersionStatus_AVAILABLE},
+		},
+	}
+
+	// Default policy is the single latest version
+	policy := &tfproto.FileSystemStoragePathSourceConfig_ServableVersionPolicy{}
+	assert.Equal(t, 32, retvalOf(testProber().checkVersionPolicy(response, policy)))
+
+	response.ModelVersionStatus[1].State = tfproto.ModelVersionStatus_AVAILABLE
+	assert.Equal(t, 0, retvalOf(testProber().checkVersionPolicy(response, policy)))
+
+	// Latest two versions
+	policy = &tfproto.FileSystemStoragePathSourceConfig_ServableVersionPolicy{
+		PolicyChoice: &tfproto.FileSystemStoragePathSourceConfig_ServableVersionPolicy_Latest_{
+			Latest: &tfproto.FileSystemStoragePathSourceConfig_ServableVersionPolicy_Latest{NumVersions: 2},
+		},
+	}
+	assert.Equal(t, 0, retvalOf(testProber().checkVersionPolicy(response, policy)))
+
+	policy.GetLatest().NumVersions = 3
+	assert.Equal(t, 34, retvalOf(testProber().checkVersionPolicy(response, policy)))
+}
+
+func TestVersionPolicySpecific(t *testing.T) {
+	response := &tfproto.GetModelStatusResponse{
+		ModelVersionStatus: []*tfproto.ModelVersionStatus{
+			{Version: 1, State: tfproto.ModelVersionStatus_AVAILABLE},
+			{Version: 3, State: tfproto.ModelVersionStatus_AVAILABLE},
+		},
+	}
+	policy := &tfproto.FileSystemStoragePathSourceConfig_ServableVersionPolicy{
+		PolicyChoice: &tfproto.FileSystemStoragePathSourceConfig_ServableVersionPolicy_Specific_{
+			Specific: &tfproto.FileSystemStoragePathSourceConfig_ServableVersionPolicy_Specific{Versions: []int64{1, 3}},
+		},
+	}
+	assert.Equal(t, 0, retvalOf(testProber().checkVersionPolicy(response, policy)))
+
+	policy.GetSpecific().Versions = []int64{1, 2}
+	assert.Equal(t, 12, retvalOf(testProber().checkVersionPolicy(response, policy)))
+}
+
+func TestVersionPolicyAll(t *testing.T) {
+	response := &tfproto.GetModelStatusResponse{
+		ModelVersionStatus: []*tfproto.ModelVersionStatus{
+			{Version: 1, State: tfproto.ModelVersionStatus_END, Status: &tfproto.StatusProto{}},
+			{Version: 2, State: tfproto.ModelVersionStatus_AVAILABLE},
+			{Version: 3, State: tfproto.ModelVersionStatus_AVAILABLE},
+		},
+	}
+	policy := &tfproto.FileSystemStoragePathSourceConfig_ServableVersionPolicy{
+		PolicyChoice: &tfproto.FileSystemStoragePathSourceConfig_ServableVersionPolicy_All_{
+			All: &tfproto.FileSystemStoragePathSourceConfig_ServableVersionPolicy_All{},
+		},
+	}
+
+	// A cleanly ended version was removed from disk
+	assert.Equal(t, 0, retvalOf(testProber().checkVersionPolicy(response, policy)))
+
+	// A failed load is not
+	response.ModelVersionStatus[0].Status.ErrorCode = tfproto.Code_NOT_FOUND
+	assert.Equal(t, 65, retvalOf(testProber().checkVersionPolicy(response, policy)))
+}
+
+func TestVersionPolicyResultVersion(t *testing.T) {
+	response := &tfproto.GetModelStatusResponse{
+		ModelVersionStatus: []*tfproto.ModelVersionStatus{
+			{Version: 3, State: tfproto.ModelVersionStatus_AVAILABLE},
+			{Version: 2, State: tfproto.ModelVersionStatus_LOADING},
+		},
+	}
+	policy := &tfproto.FileSystemStoragePathSourceConfig_ServableVersionPolicy{
+		PolicyChoice: &tfproto.FileSystemStoragePathSourceConfig_ServableVersionPolicy_Latest_{
+			Latest: &tfproto.FileSystemStoragePathSourceConfig_ServableVersionPolicy_Latest{NumVersions: 2},
+		},
+	}
+
+	// the failing version is reported, not the one selection would pick
+	results := []ModelResult{
+		{Model: Model{Name: "half_plus_two", Policy: policy}, Response: response},
+		{Model: Model{Name: "half_plus_two", Label: "canary"}, Response: &tfproto.GetModelStatusResponse{
+			ModelVersionStatus: []*tfproto.ModelVersionStatus{{Version: 2, State: tfproto.ModelVersionStatus_LOADING}},
+		}},
+	}
+	assert.Equal(t, 32, testProber().checkModelResults(results, 0))
+	for _, result := range results {
+		assert.Equal(t, 32, result.ExitCode)
+		assert.Equal(t, int64(2), result.Version)
+		assert.Equal(t, tfproto.ModelVersionStatus_LOADING, result.State)
+	}
+
+	// all passing, the first required version
+	response.ModelVersionStatus[1].State = tfproto.ModelVersionStatus_AVAILABLE
+	retval, version := testProber().checkVersionPolicy(response, policy)
+	assert.Equal(t, 0, retval)
+	assert.Equal(t, int64(3), version)
+}
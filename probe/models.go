@@ -0,0 +1,275 @@
+//
+// Copyright 2020 Cody Collier <cody@telnet.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package probe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/codycollier/tfs-model-status-probe/tfproto/tfproto"
+)
+
+// Model is a model to check, with an optional version (0 for any) or
+// version label. Models read from a model config file carry their version
+// policy instead.
+type Model struct {
+	Name    string
+	Version int64
+	Label   string
+	Policy  *tfproto.FileSystemStoragePathSourceConfig_ServableVersionPolicy
+}
+
+func (t Model) String() string {
+	switch {
+	case t.Version != 0:
+		return fmt.Sprintf("%v:%v", t.Name, t.Version)
+	case t.Label != "":
+		return fmt.Sprintf("%v@%v", t.Name, t.Label)
+	default:
+		return t.Name
+	}
+}
+
+// ParseModel parses a "name", "name:version" or "name@label" model argument
+func ParseModel(value string) (Model, error) {
+	target := Model{Name: value}
+	if i := strings.LastIndex(value, "@"); i >= 0 {
+		target.Name, target.Label = value[:i], value[i+1:]
+		if target.Label == "" {
+			return Model{}, fmt.Errorf("missing version label in %q", value)
+		}
+	} else if i := strings.LastIndex(value, ":"); i >= 0 {
+		var err error
+		target.Name = value[:i]
+		target.Version, err = strconv.ParseInt(value[i+1:], 10, 64)
+		if err != nil || target.Version <= 0 {
+			return Model{}, fmt.Errorf("invalid model version in %q", value)
+		}
+	}
+	if target.Name == "" {
+		return Model{}, fmt.Errorf("missing model name in %q", value)
+	}
+	return target, nil
+}
+
+// statusFetcher calls model status for a target over some transport
+type statusFetcher func(ctx context.Context, target Model) (*tfproto.GetModelStatusResponse, error)
+
+// Wrap a fetcher to request every version of the model, leaving the version
+// filtering to checkServableResponse. Useful for servers which do not filter.
+func clientSideVersionFilter(fetch statusFetcher) statusFetcher {
+	return func(ctx context.Context, target Model) (*tfproto.GetModelStatusResponse, error) {
+		target.Version = 0
+		return fetch(ctx, target)
+	}
+}
+
+// modelNotFoundError marks a NotFound for a version of a model which was
+// confirmed to be missing as a whole
+type modelNotFoundError struct {
+	err error
+}
+
+func (e *modelNotFoundError) Error() string {
+	return e.err.Error()
+}
+
+func (e *modelNotFoundError) Unwrap() error {
+	return e.err
+}
+
+func (e *modelNotFoundError) GRPCStatus() *status.Status {
+	return status.Convert(e.err)
+}
+
+// Wrap a fetcher to tell a missing model from a missing version. The server
+// answers "Could not find version N of model M" for both, so a version which
+// is not found is followed by one call for every version of the model.
+func confirmModelNotFound(fetch statusFetcher) statusFetcher {
+	return func(ctx context.Context, target Model) (*tfproto.GetModelStatusResponse, error) {
+		response, err := fetch(ctx, target)
+		if target.Version == 0 || status.Code(err) != codes.NotFound {
+			return response, err
+		}
+		unversioned := target
+		unversioned.Version = 0
+		if _, modelErr := fetch(ctx, unversioned); status.Code(modelErr) == codes.NotFound {
+			return nil, &modelNotFoundError{modelErr}
+		}
+		return nil, err
+	}
+}
+
+// ModelResult is the outcome of checking a single model. Version, State and
+// the error code and message are those of the version the check selected,
+// if any: the requested or labeled version, the failing version of a version
+// policy, or else the version picked by the selection strategy. Latency is
+// that of the model status call.
+type ModelResult struct {
+	Model        Model
+	Response     *tfproto.GetModelStatusResponse
+	Err          error
+	Latency      time.Duration
+	Version      int64
+	State        tfproto.ModelVersionStatus_State
+	ErrorCode    tfproto.Code
+	ErrorMessage string
+	ExitCode     int
+}
+
+// Call model status for every target concurrently. Results are returned in
+// the same order as the targets.
+func fetchModelStatuses(ctx context.Context, fetch statusFetcher, targets []Model) []ModelResult {
+	results := make([]ModelResult, len(targets))
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		wg.Add(1)
+		go func(i int, target Model) {
+			defer wg.Done()
+			start := time.Now()
+			response, err := fetch(ctx, target)
+			results[i] = ModelResult{Model: target, Response: response, Err: err, Latency: time.Since(start)}
+		}(i, target)
+	}
+	wg.Wait()
+	return results
+}
+
+// Map the outcome of a model status call to a return value, with the
+// version checked, or 0 when the check selects the version
+func (p *Prober) checkModelResult(result ModelResult) (int, int64) {
+	p.log.Printf("ModelStatusResponse: %v\n", result.Response)
+	if err := result.Err; err != nil {
+		var transportErr *restTransportError
+		if errors.As(err, &transportErr) {
+			p.log.Printf("Error connecting to rest api: %v\n", err)
+			return restTransportErrorCode(err), 0
+		}
+		// an unknown label is rejected by the server
+		if result.Model.Label != "" && isLabelRejected(err) {
+			p.log.Printf("Version label not found: %v\n", err)
+			return ExitVersionLabelInvalid, 0
+		}
+		if status.Code(err) == codes.NotFound {
+			// a specific version can be missing while the model exists
+			var modelErr *modelNotFoundError
+			if result.Model.Version != 0 && !errors.As(err, &modelErr) && !strings.Contains(status.Convert(err).Message(), "any versions") {
+				p.log.Printf("Version not found: %v\n", err)
+				return ExitVersionNotFound, 0
+			}
+			p.log.Printf("Model not found: %v\n", err)
+			return ExitModelNotFound, 0
+		}
+		p.log.Printf("Error calling tfs: %v\n", err)
+		return ExitRPCFailed, 0
+	}
+	if result.Model.Policy != nil {
+		return p.checkVersionPolicy(result.Response, result.Model.Policy)
+	}
+	if result.Model.Label != "" {
+		return p.checkLabelResponse(result.Response, result.Model.Label)
+	}
+	return p.checkServableResponse(result.Response, result.Model.Version), result.Model.Version
+}
+
+// Report whether an error is the server rejecting a version label
+func isLabelRejected(err error) bool {
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		return true
+	case codes.NotFound:
+		return strings.Contains(status.Convert(err).Message(), "label")
+	default:
+		return false
+	}
+}
+
+// Parse the proto msg response for a version label request. The server
+// resolves the label, so exactly one version is expected in the response,
+// which is returned as the version checked.
+func (p *Prober) checkLabelResponse(response *tfproto.GetModelStatusResponse, label string) (int, int64) {
+	if len(response.ModelVersionStatus) > 1 {
+		p.log.Printf("Expected a single version for label %v, got %v\n", label, len(response.ModelVersionStatus))
+		return ExitVersionLabelInvalid, 0
+	}
+	if len(response.ModelVersionStatus) == 1 {
+		version := response.ModelVersionStatus[0].Version
+		p.log.Printf("Version label %v is version %v\n", label, version)
+		return p.checkServableResponse(response, version), version
+	}
+	return p.checkServableResponse(response, 0), 0
+}
+
+// Check each model status result, filling in the per model outcome, and
+// return the aggregate return value with a per model breakdown when
+// checking several
+func (p *Prober) checkModelResults(results []ModelResult, minAvailable int) int {
+	retvals := make([]int, len(results))
+	for i := range results {
+		result := &results[i]
+		if len(results) > 1 {
+			p.log.Printf("Model: %v\n", result.Model)
+		}
+		var version int64
+		retvals[i], version = p.checkModelResult(*result)
+		result.ExitCode = retvals[i]
+		if selected := selectServable(result.Response, version, p.config.Selection); selected != nil {
+			result.Version = selected.Version
+			result.State = selected.State
+			result.ErrorCode = selected.GetStatus().GetErrorCode()
+			result.ErrorMessage = selected.GetStatus().GetErrorMessage()
+		}
+	}
+
+	retval := aggregateRetval(retvals, minAvailable)
+	if len(results) > 1 {
+		for i, result := range results {
+			p.log.Printf("Model %v: %v\n", result.Model, retvals[i])
+		}
+	}
+	return retval
+}
+
+// Combine per model return values into a single return value. At least
+// minAvailable models must be AVAILABLE (all models when 0). On failure, the
+// first non-zero return value is used.
+func aggregateRetval(retvals []int, minAvailable int) int {
+	if minAvailable <= 0 {
+		minAvailable = len(retvals)
+	}
+	available := 0
+	firstFailure := 0
+	for _, retval := range retvals {
+		if retval == 0 {
+			available++
+		} else if firstFailure == 0 {
+			firstFailure = retval
+		}
+	}
+	if available >= minAvailable {
+		return 0
+	}
+	return firstFailure
+}
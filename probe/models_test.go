@@ -0,0 +1,175 @@
+//
+// Copyright 2020 Cody Collier <cody@telnet.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package probe
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/codycollier/tfs-model-status-probe/tfproto/tfproto"
+)
+
+func TestParseModel(t *testing.T) {
+	target, err := ParseModel("half_plus_two")
+	assert.Nil(t, err)
+	assert.Equal(t, Model{Name: "half_plus_two"}, target)
+
+	target, err = ParseModel("half_plus_two:123")
+	assert.Nil(t, err)
+	assert.Equal(t, Model{Name: "half_plus_two", Version: 123}, target)
+
+	target, err = ParseModel("half_plus_two@canary")
+	assert.Nil(t, err)
+	assert.Equal(t, Model{Name: "half_plus_two", Label: "canary"}, target)
+	assert.Equal(t, "half_plus_two@canary", target.String())
+
+	_, err = ParseModel("half_plus_two@")
+	assert.NotNil(t, err)
+	_, err = ParseModel("half_plus_two:latest")
+	assert.NotNil(t, err)
+	_, err = ParseModel(":123")
+	assert.NotNil(t, err)
+}
+
+func TestAggregateRetval(t *testing.T) {
+	// All must be available
+	assert.Equal(t, 0, aggregateRetval([]int{0}, 0))
+	assert.Equal(t, 32, aggregateRetval([]int{32}, 0))
+	assert.Equal(t, 0, aggregateRetval([]int{0, 0, 0}, 0))
+	assert.Equal(t, 10, aggregateRetval([]int{0, 10, 32}, 0))
+
+	// At least N must be available
+	assert.Equal(t, 0, aggregateRetval([]int{0, 10, 0}, 2))
+	assert.Equal(t, 10, aggregateRetval([]int{0, 10, 32}, 2))
+}
+
+func TestFetchModelStatuses(t *testing.T) {
+	fetch := func(ctx context.Context, target Model) (*tfproto.GetModelStatusResponse, error) {
+		if target.Name == "missing" {
+			return nil, status.Error(codes.NotFound, "Could not find any versions of model missing")
+		}
+		return &tfproto.GetModelStatusResponse{
+			ModelVersionStatus: []*tfproto.ModelVersionStatus{
+				{
+					Version: 123,
+					State:   tfproto.ModelVersionStatus_AVAILABLE,
+				},
+			},
+		}, nil
+	}
+	targets := []Model{
+		{Name: "half_plus_two"},
+		{Name: "missing"},
+		{Name: "half_plus_three", Version: 123},
+	}
+	results := fetchModelStatuses(context.Background(), fetch, targets)
+	assert.Len(t, results, 3)
+	for i, result := range results {
+		assert.Equal(t, targets[i], result.Model)
+	}
+	assert.Equal(t, 0, retvalOf(testProber().checkModelResult(results[0])))
+	assert.Equal(t, 10, retvalOf(testProber().checkModelResult(results[1])))
+	assert.Equal(t, 0, retvalOf(testProber().checkModelResult(results[2])))
+}
+
+func TestCheckModelResultErrors(t *testing.T) {
+	result := ModelResult{
+		Model: Model{Name: "half_plus_two", Version: 7},
+		Err:   status.Error(codes.NotFound, "Could not find version 7 of model half_plus_two"),
+	}
+	assert.Equal(t, 12, retvalOf(testProber().checkModelResult(result)))
+
+	result.Err = status.Error(codes.NotFound, "Could not find any versions of model half_plus_two")
+	assert.Equal(t, 10, retvalOf(testProber().checkModelResult(result)))
+
+	result.Err = status.Error(codes.Unavailable, "connection closed")
+	assert.Equal(t, 3, retvalOf(testProber().checkModelResult(result)))
+}
+
+func TestCheckLabelResponse(t *testing.T) {
+	response := &tfproto.GetModelStatusResponse{
+		ModelVersionStatus: []*tfproto.ModelVersionStatus{
+			{
+				Version: 301,
+				State:   tfproto.ModelVersionStatus_LOADING,
+			},
+		},
+	}
+	assert.Equal(t, 32, retvalOf(testProber().checkLabelResponse(response, "canary")))
+
+	response.ModelVersionStatus[0].State = tfproto.ModelVersionStatus_AVAILABLE
+	assert.Equal(t, 0, retvalOf(testProber().checkLabelResponse(response, "canary")))
+
+	// The label was not resolved by the server
+	response.ModelVersionStatus = append(response.ModelVersionStatus, &tfproto.ModelVersionStatus{
+		Version: 101,
+		State:   tfproto.ModelVersionStatus_AVAILABLE,
+	})
+	assert.Equal(t, 13, retvalOf(testProber().checkLabelResponse(response, "canary")))
+
+	assert.Equal(t, 11, retvalOf(testProber().checkLabelResponse(&tfproto.GetModelStatusResponse{}, "canary")))
+}
+
+func TestCheckModelResultLabelRejected(t *testing.T) {
+	result := ModelResult{
+		Model: Model{Name: "half_plus_two", Label: "canary"},
+		Err:   status.Error(codes.InvalidArgument, "Unrecognized servable version label: canary"),
+	}
+	assert.Equal(t, 13, retvalOf(testProber().checkModelResult(result)))
+
+	result.Err = status.Error(codes.NotFound, "Could not find any versions of model half_plus_two")
+	assert.Equal(t, 10, retvalOf(testProber().checkModelResult(result)))
+}
+
+func TestClientSideVersionFilter(t *testing.T) {
+	var requested Model
+	fetch := func(ctx context.Context, target Model) (*tfproto.GetModelStatusResponse, error) {
+		requested = target
+		return &tfproto.GetModelStatusResponse{}, nil
+	}
+	target := Model{Name: "half_plus_two", Version: 123}
+
+	_, _ = fetch(context.Background(), target)
+	assert.Equal(t, int64(123), requested.Version)
+
+	_, _ = clientSideVersionFilter(fetch)(context.Background(), target)
+	assert.Equal(t, int64(0), requested.Version)
+}
+
+func TestConfirmModelNotFound(t *testing.T) {
+	var calls int
+	fetch := func(ctx context.Context, target Model) (*tfproto.GetModelStatusResponse, error) {
+		calls++
+		if target.Name == "half_plus_two" && target.Version == 0 {
+			return &tfproto.GetModelStatusResponse{}, nil
+		}
+		return nil, status.Errorf(codes.NotFound, "Could not find version %v of model %v", target.Version, target.Name)
+	}
+	check := func(target Model) int {
+		_, err := confirmModelNotFound(fetch)(context.Background(), target)
+		return retvalOf(testProber().checkModelResult(ModelResult{Model: target, Err: err}))
+	}
+
+	assert.Equal(t, 12, check(Model{Name: "half_plus_two", Version: 5}))
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, 10, check(Model{Name: "missing", Version: 5}))
+	assert.Equal(t, 4, calls)
+}
@@ -0,0 +1,240 @@
+//
+// Copyright 2020 Cody Collier <cody@telnet.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package probe
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io/ioutil"
+	"math"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/codycollier/tfs-model-status-probe/tfproto/tfproto"
+)
+
+// smokeTest is an inference request sent after a successful status check,
+// with optional expectations on the response
+type smokeTest struct {
+	request   *tfproto.PredictRequest
+	expect    *tfproto.PredictResponse
+	tolerance float64
+}
+
+// ReadProtoFile reads a proto message from a file, in json (.json) or text
+// proto format
+func ReadProtoFile(path string, m proto.Message) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		return protojson.Unmarshal(data, m)
+	}
+	return prototext.Unmarshal(data, m)
+}
+
+// Build the smoke test request for a model. The model spec in the request
+// file takes precedence, and defaults to the checked model.
+func (s *smokeTest) predictRequest(target Model) *tfproto.PredictRequest {
+	request := proto.Clone(s.request).(*tfproto.PredictRequest)
+	if request.ModelSpec == nil {
+		request.ModelSpec = &tfproto.ModelSpec{}
+	}
+	if request.ModelSpec.Name == "" {
+		request.ModelSpec.Name = target.Name
+	}
+	if request.ModelSpec.VersionChoice == nil {
+		if target.Version != 0 {
+			request.ModelSpec.VersionChoice = &tfproto.ModelSpec_Version{Version: wrapperspb.Int64(target.Version)}
+		} else if target.Label != "" {
+			request.ModelSpec.VersionChoice = &tfproto.ModelSpec_VersionLabel{VersionLabel: target.Label}
+		}
+	}
+	return request
+}
+
+// Call PredictionService.Predict() and return response
+func callPredict(ctx context.Context, client tfproto.PredictionServiceClient, request *tfproto.PredictRequest) (*tfproto.PredictResponse, error) {
+	response, err := client.Predict(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// Run the smoke test against a model and map the outcome to a return value
+func (p *Prober) runSmokeTest(ctx context.Context, client tfproto.PredictionServiceClient, target Model, s *smokeTest) int {
+	request := s.predictRequest(target)
+	p.log.Printf("Smoke test: model %v, signature %q\n", request.ModelSpec.Name, request.ModelSpec.SignatureName)
+	response, err := callPredict(ctx, client, request)
+	if err != nil {
+		p.log.Printf("Error calling predict: %v\n", err)
+		return ExitPredictFailed
+	}
+	return p.checkPredictResponse(response, s.expect, s.tolerance)
+}
+
+// Parse the predict response and map to an appropriate return value. Each
+// expected output must be present, with the same dtype and shape, and with
+// values within the tolerance when expected values are given.
+func (p *Prober) checkPredictResponse(response, expect *tfproto.PredictResponse, tolerance float64) int {
+	if expect == nil {
+		p.log.Printf("Smoke test succeeded with %v outputs\n", len(response.Outputs))
+		return 0
+	}
+
+	names := make([]string, 0, len(expect.Outputs))
+	for name := range expect.Outputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		expected := expect.Outputs[name]
+		actual, ok := response.Outputs[name]
+		if !ok {
+			p.log.Printf("Smoke test output %v is missing\n", name)
+			return ExitPredictOutputMissing
+		}
+		if actual.Dtype != expected.Dtype {
+			p.log.Printf("Smoke test output %v has dtype %v, expected %v\n", name, actual.Dtype, expected.Dtype)
+			return ExitPredictDtypeMismatch
+		}
+		if !shapeMatches(actual.TensorShape, expected.TensorShape) {
+			p.log.Printf("Smoke test output %v has shape %v, expected %v\n", name, shapeString(actual.TensorShape), shapeString(expected.TensorShape))
+			return ExitPredictShapeMismatch
+		}
+		if !valuesMatch(actual, expected, tolerance) {
+			p.log.Printf("Smoke test output %v values differ from expected\n", name)
+			return ExitPredictValuesMismatch
+		}
+	}
+
+	p.log.Println("Smoke test succeeded")
+	return 0
+}
+
+// Compare tensor shapes, with an expected dim size of -1 matching any size
+func shapeMatches(actual, expected *tfproto.TensorShapeProto) bool {
+	if expected.GetUnknownRank() {
+		return true
+	}
+	actualDims, expectedDims := actual.GetDim(), expected.GetDim()
+	if len(actualDims) != len(expectedDims) {
+		return false
+	}
+	for i := range expectedDims {
+		if expectedDims[i].Size != -1 && expectedDims[i].Size != actualDims[i].Size {
+			return false
+		}
+	}
+	return true
+}
+
+func shapeString(shape *tfproto.TensorShapeProto) string {
+	sizes := make([]string, len(shape.GetDim()))
+	for i, dim := range shape.GetDim() {
+		sizes[i] = strconv.FormatInt(dim.Size, 10)
+	}
+	return "[" + strings.Join(sizes, ",") + "]"
+}
+
+// Compare tensor values, when the expected tensor has values at all
+func valuesMatch(actual, expected *tfproto.TensorProto, tolerance float64) bool {
+	if expected.Dtype == tfproto.DataType_DT_STRING {
+		if len(expected.StringVal) == 0 {
+			return true
+		}
+		if len(actual.StringVal) != len(expected.StringVal) {
+			return false
+		}
+		for i := range expected.StringVal {
+			if !bytes.Equal(actual.StringVal[i], expected.StringVal[i]) {
+				return false
+			}
+		}
+		return true
+	}
+
+	expectedValues := tensorValues(expected)
+	if len(expectedValues) == 0 {
+		return true
+	}
+	actualValues := tensorValues(actual)
+	if len(actualValues) != len(expectedValues) {
+		return false
+	}
+	for i := range expectedValues {
+		if math.Abs(actualValues[i]-expectedValues[i]) > tolerance {
+			return false
+		}
+	}
+	return true
+}
+
+// Return the numeric values of a tensor, from either the typed value fields
+// or the little endian tensor_content
+func tensorValues(t *tfproto.TensorProto) []float64 {
+	var values []float64
+	content := t.TensorContent
+	switch t.Dtype {
+	case tfproto.DataType_DT_FLOAT:
+		for _, v := range t.FloatVal {
+			values = append(values, float64(v))
+		}
+		for i := 0; i+4 <= len(content); i += 4 {
+			values = append(values, float64(math.Float32frombits(binary.LittleEndian.Uint32(content[i:]))))
+		}
+	case tfproto.DataType_DT_DOUBLE:
+		values = append(values, t.DoubleVal...)
+		for i := 0; i+8 <= len(content); i += 8 {
+			values = append(values, math.Float64frombits(binary.LittleEndian.Uint64(content[i:])))
+		}
+	case tfproto.DataType_DT_INT32:
+		for _, v := range t.IntVal {
+			values = append(values, float64(v))
+		}
+		for i := 0; i+4 <= len(content); i += 4 {
+			values = append(values, float64(int32(binary.LittleEndian.Uint32(content[i:]))))
+		}
+	case tfproto.DataType_DT_INT64:
+		for _, v := range t.Int64Val {
+			values = append(values, float64(v))
+		}
+		for i := 0; i+8 <= len(content); i += 8 {
+			values = append(values, float64(int64(binary.LittleEndian.Uint64(content[i:]))))
+		}
+	case tfproto.DataType_DT_BOOL:
+		for _, v := range t.BoolVal {
+			if v {
+				values = append(values, 1)
+			} else {
+				values = append(values, 0)
+			}
+		}
+	}
+	return values
+}
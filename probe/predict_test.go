@@ -0,0 +1,147 @@
+//
+// Copyright 2020 Cody Collier <cody@telnet.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package probe
+
+import (
+	"context"
+	"encoding/binary"
+	"io/ioutil"
+	"math"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/codycollier/tfs-model-status-probe/tfproto/tfproto"
+)
+
+// predictionServiceStub is a PredictionServiceClient with canned responses
+type predictionServiceStub struct {
+	response *tfproto.PredictResponse
+	metadata *tfproto.GetModelMetadataResponse
+	err      error
+}
+
+func (p *predictionServiceStub) Predict(ctx context.Context, in *tfproto.PredictRequest, opts ...grpc.CallOption) (*tfproto.PredictResponse, error) {
+	return p.response, p.err
+}
+
+func (p *predictionServiceStub) GetModelMetadata(ctx context.Context, in *tfproto.GetModelMetadataRequest, opts ...grpc.CallOption) (*tfproto.GetModelMetadataResponse, error) {
+	return p.metadata, p.err
+}
+
+func floatTensor(values ...float32) *tfproto.TensorProto {
+	return &tfproto.TensorProto{
+		Dtype: tfproto.DataType_DT_FLOAT,
+		TensorShape: &tfproto.TensorShapeProto{
+			Dim: []*tfproto.TensorShapeProto_Dim{{Size: int64(len(values))}},
+		},
+		FloatVal: values,
+	}
+}
+
+func TestReadProtoFile(t *testing.T) {
+	dir := t.TempDir()
+
+	jsonPath := filepath.Join(dir, "request.json")
+	jsonRequest := `{
+		"model_spec": {"signature_name": "serving_default"},
+		"inputs": {"x": {"dtype": "DT_FLOAT", "tensor_shape": {"dim": [{"size": "3"}]}, "float_val": [1.0, 2.0, 5.0]}}
+	}`
+	assert.Nil(t, ioutil.WriteFile(jsonPath, []byte(jsonRequest), 0644))
+	request := &tfproto.PredictRequest{}
+	assert.Nil(t, ReadProtoFile(jsonPath, request))
+	assert.Equal(t, "serving_default", request.ModelSpec.SignatureName)
+	assert.Equal(t, []float32{1, 2, 5}, request.Inputs["x"].FloatVal)
+
+	textPath := filepath.Join(dir, "expect.textproto")
+	textExpect := `outputs { key: "y" value { dtype: DT_FLOAT tensor_shape { dim { size: 3 } } float_val: [2.5, 3.0, 4.5] } }`
+	assert.Nil(t, ioutil.WriteFile(textPath, []byte(textExpect), 0644))
+	expect := &tfproto.PredictResponse{}
+	assert.Nil(t, ReadProtoFile(textPath, expect))
+	assert.Equal(t, []float32{2.5, 3, 4.5}, expect.Outputs["y"].FloatVal)
+}
+
+func TestSmokeTestPredictRequest(t *testing.T) {
+	s := &smokeTest{request: &tfproto.PredictRequest{}}
+	request := s.predictRequest(Model{Name: "half_plus_two", Version: 123})
+	assert.Equal(t, "half_plus_two", request.ModelSpec.Name)
+	assert.Equal(t, int64(123), request.ModelSpec.GetVersion().GetValue())
+
+	// The request file takes precedence
+	s.request.ModelSpec = &tfproto.ModelSpec{Name: "half_plus_three", SignatureName: "regress_x_to_y"}
+	request = s.predictRequest(Model{Name: "half_plus_two", Label: "canary"})
+	assert.Equal(t, "half_plus_three", request.ModelSpec.Name)
+	assert.Equal(t, "regress_x_to_y", request.ModelSpec.SignatureName)
+	assert.Equal(t, "canary", request.ModelSpec.GetVersionLabel())
+	assert.Nil(t, s.request.ModelSpec.VersionChoice)
+}
+
+func TestCheckPredictResponse(t *testing.T) {
+	expect := &tfproto.PredictResponse{
+		Outputs: map[string]*tfproto.TensorProto{"y": floatTensor(2.5, 3.0, 4.5)},
+	}
+	response := &tfproto.PredictResponse{
+		Outputs: map[string]*tfproto.TensorProto{"y": floatTensor(2.5, 3.0, 4.5)},
+	}
+	assert.Equal(t, 0, testProber().checkPredictResponse(response, nil, 1e-6))
+	assert.Equal(t, 0, testProber().checkPredictResponse(response, expect, 1e-6))
+
+	// Values within tolerance
+	response.Outputs["y"] = floatTensor(2.5, 3.0, 4.501)
+	assert.Equal(t, 44, testProber().checkPredictResponse(response, expect, 1e-6))
+	assert.Equal(t, 0, testProber().checkPredictResponse(response, expect, 0.01))
+
+	// Shape, with -1 for any size
+	response.Outputs["y"] = floatTensor(2.5, 3.0)
+	assert.Equal(t, 43, testProber().checkPredictResponse(response, expect, 1e-6))
+	expect.Outputs["y"] = &tfproto.TensorProto{
+		Dtype: tfproto.DataType_DT_FLOAT,
+		TensorShape: &tfproto.TensorShapeProto{
+			Dim: []*tfproto.TensorShapeProto_Dim{{Size: -1}},
+		},
+	}
+	assert.Equal(t, 0, testProber().checkPredictResponse(response, expect, 1e-6))
+
+	// Dtype
+	response.Outputs["y"].Dtype = tfproto.DataType_DT_DOUBLE
+	assert.Equal(t, 42, testProber().checkPredictResponse(response, expect, 1e-6))
+
+	// Missing output
+	delete(response.Outputs, "y")
+	assert.Equal(t, 41, testProber().checkPredictResponse(response, expect, 1e-6))
+}
+
+func TestTensorValuesContent(t *testing.T) {
+	content := make([]byte, 8)
+	binary.LittleEndian.PutUint32(content[0:], math.Float32bits(2.5))
+	binary.LittleEndian.PutUint32(content[4:], math.Float32bits(3.0))
+	tensor := &tfproto.TensorProto{Dtype: tfproto.DataType_DT_FLOAT, TensorContent: content}
+	assert.Equal(t, []float64{2.5, 3.0}, tensorValues(tensor))
+}
+
+func TestRunSmokeTestError(t *testing.T) {
+	client := &predictionServiceStub{err: status.Error(codes.FailedPrecondition, "Serving signature name: \"x\" not found")}
+	s := &smokeTest{request: &tfproto.PredictRequest{}}
+	assert.Equal(t, 40, testProber().runSmokeTest(context.Background(), client, Model{Name: "half_plus_two"}, s))
+
+	client = &predictionServiceStub{response: &tfproto.PredictResponse{}}
+	assert.Equal(t, 0, testProber().runSmokeTest(context.Background(), client, Model{Name: "half_plus_two"}, s))
+}
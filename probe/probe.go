@@ -0,0 +1,396 @@
+//
+// Copyright 2020 Cody Collier <cody@telnet.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package probe checks the model status of TensorFlow Serving, over grpc or
+// the REST api, and maps the outcome to the exit codes of the command line
+// probe (tfs_model_status_probe).
+//
+//	p, err := probe.New(probe.Config{
+//		Addr:   "localhost:8500",
+//		Models: []probe.Model{{Name: "half_plus_two"}},
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	defer p.Close()
+//	result := p.Check(ctx)
+//	if result.Err != nil {
+//		return result.Err
+//	}
+package probe
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/codycollier/tfs-model-status-probe/tfproto/tfproto"
+)
+
+// Config holds the options of a Prober. Zero values take the defaults of
+// the command line probe, noted below. Reconnect and PredictTolerance are
+// the exceptions: their zero values are off and an exact comparison.
+type Config struct {
+	Addr           string        // host:port of the grpc (8500) or REST (8501) api, default localhost:9000
+	Protocol       string        // grpc or rest, default grpc
+	Models         []Model       // default a single model named "default"
+	MinAvailable   int           // models which must be AVAILABLE, default all
+	VersionFilter  string        // filter versions on the server or the client, default server
+	ConnectTimeout time.Duration // default 3s
+	RPCTimeout     time.Duration // default 10s
+	TLS            *tls.Config   // nil for a plaintext connection
+
+	// Dialer replaces the network dial of the grpc connection, for example
+	// with an in-memory bufconn listener
+	Dialer func(ctx context.Context, addr string) (net.Conn, error)
+
+	// Selection picks the version to check when none is requested, one of
+	// the Select strategies, default SelectAnyAvailable
+	Selection string
+
+	// Constraints on the AVAILABLE version: the requested version, or else
+	// the highest AVAILABLE version, which TFS serves by default. Zero values
+	// are unconstrained.
+	MinVersion            int64
+	MaxVersion            int64
+	ExpectLatestAvailable bool // the highest version present must be AVAILABLE
+	MaxAvailableVersions  int
+
+	// MaxVersionAge fails a version older than this, reading the version as
+	// a timestamp: unix seconds, or a layout such as YYYYMMDDhhmm in UTC
+	MaxVersionAge     time.Duration
+	VersionTimeLayout string
+
+	// Wait polls with backoff until the models are AVAILABLE
+	Wait            bool
+	WaitTimeout     time.Duration // default 5m
+	PollInterval    time.Duration // default 1s
+	MaxPollInterval time.Duration // default 30s
+	Reconnect       bool          // reconnect after failures when waiting, else a failure to connect ends the wait; on by default on the command line
+
+	// Optional checks of the first model once the models are AVAILABLE,
+	// only over grpc
+	PredictRequest   *tfproto.PredictRequest
+	PredictExpect    *tfproto.PredictResponse
+	PredictTolerance float64 // 0 compares exactly, the command line default is 1e-6
+	ExpectSignature  *tfproto.SignatureDefMap
+
+	// Logger receives the same progress lines as the command line probe,
+	// which are discarded when nil
+	Logger *log.Logger
+}
+
+// Result is the outcome of a Check. State and Version are those of the
+// first model. The dial and rpc latencies are those of the last connection
+// and model status poll, with no dial latency when the connection was kept.
+// SignatureDiffs holds the differences found by the signature check, if run.
+type Result struct {
+	ExitCode       int
+	Category       Category
+	State          tfproto.ModelVersionStatus_State
+	Version        int64
+	Models         []ModelResult
+	Err            error // an *Error when ExitCode is non-zero
+	Latency        time.Duration
+	DialLatency    time.Duration
+	RPCLatency     time.Duration
+	SignatureDiffs []SignatureDiff
+}
+
+// Prober checks model status against a TFS server. The connection is kept
+// between checks, and concurrent calls to Check are serialized.
+type Prober struct {
+	config Config
+	log    *log.Logger
+	c      *connector
+	fetch  statusFetcher
+	timing phaseTiming
+	mu     sync.Mutex
+}
+
+// phaseTiming keeps the latency of the dial and rpc phases of a check
+type phaseTiming struct {
+	dial time.Duration
+	rpc  time.Duration
+}
+
+// Connect, keeping the dial latency
+func (p *Prober) connect(ctx context.Context) (statusFetcher, int, error) {
+	start := time.Now()
+	fetch, retval, err := p.c.connect(ctx)
+	p.timing.dial = time.Since(start)
+	return fetch, retval, err
+}
+
+// Call model status for the models, keeping the rpc latency
+func (p *Prober) fetchModelStatuses(ctx context.Context, models []Model) []ModelResult {
+	start := time.Now()
+	results := fetchModelStatuses(ctx, p.fetch, models)
+	p.timing.rpc = time.Since(start)
+	return results
+}
+
+// New validates the config and returns a Prober. Invalid options are
+// reported as an *Error with ExitInvalidConfig.
+func New(config Config) (*Prober, error) {
+	setDefaults(&config)
+	if err := validate(config); err != nil {
+		return nil, newError(ExitInvalidConfig, "", err)
+	}
+	p := &Prober{config: config, log: config.Logger}
+	if p.log == nil {
+		p.log = log.New(ioutil.Discard, "", 0)
+	}
+	p.c = &connector{
+		protocol:       config.Protocol,
+		addr:           config.Addr,
+		tlsConfig:      config.TLS,
+		connectTimeout: config.ConnectTimeout,
+		versionFilter:  config.VersionFilter,
+		dialer:         config.Dialer,
+		log:            p.log,
+	}
+	return p, nil
+}
+
+// Config returns the config of the Prober, with the defaults filled in
+func (p *Prober) Config() Config {
+	return p.config
+}
+
+func setDefaults(config *Config) {
+	if config.Addr == "" {
+		config.Addr = "localhost:9000"
+	}
+	if config.Protocol == "" {
+		config.Protocol = "grpc"
+	}
+	if len(config.Models) == 0 {
+		config.Models = []Model{{Name: "default"}}
+	}
+	if config.VersionFilter == "" {
+		config.VersionFilter = "server"
+	}
+	if config.Selection == "" {
+		config.Selection = SelectAnyAvailable
+	}
+	if config.ConnectTimeout == 0 {
+		config.ConnectTimeout = time.Second * 3
+	}
+	if config.RPCTimeout == 0 {
+		config.RPCTimeout = time.Second * 10
+	}
+	if config.WaitTimeout == 0 {
+		config.WaitTimeout = time.Minute * 5
+	}
+	if config.PollInterval == 0 {
+		config.PollInterval = time.Second
+	}
+	if config.MaxPollInterval == 0 {
+		config.MaxPollInterval = time.Second * 30
+	}
+}
+
+func validate(config Config) error {
+	if config.Protocol != "grpc" && config.Protocol != "rest" {
+		return fmt.Errorf("unknown protocol: %v", config.Protocol)
+	}
+	if config.VersionFilter != "server" && config.VersionFilter != "client" {
+		return fmt.Errorf("unknown version filter: %v", config.VersionFilter)
+	}
+	if config.MinAvailable > len(config.Models) {
+		return fmt.Errorf("min available (%v) exceeds the number of models (%v)", config.MinAvailable, len(config.Models))
+	}
+	for _, model := range config.Models {
+		if model.Version != 0 && model.Label != "" {
+			return fmt.Errorf("model %v has both a version and a version label", model.Name)
+		}
+	}
+	if !isSelection(config.Selection) {
+		return fmt.Errorf("unknown version selection: %v", config.Selection)
+	}
+	if config.MinVersion < 0 || config.MaxVersion < 0 || config.MaxAvailableVersions < 0 {
+		return errors.New("version constraints must not be negative")
+	}
+	if config.ConnectTimeout < 0 || config.RPCTimeout < 0 || config.WaitTimeout < 0 || config.PollInterval < 0 || config.MaxPollInterval < 0 {
+		return errors.New("timeouts and poll intervals must not be negative")
+	}
+	if config.MaxVersionAge < 0 {
+		return errors.New("max version age must not be negative")
+	}
+	if config.VersionTimeLayout != "" && !isVersionTimeLayout(config.VersionTimeLayout) {
+		return fmt.Errorf("invalid version time layout: %v", config.VersionTimeLayout)
+	}
+	if config.MaxVersion != 0 && config.MinVersion > config.MaxVersion {
+		return fmt.Errorf("min version (%v) exceeds max version (%v)", config.MinVersion, config.MaxVersion)
+	}
+	if config.Protocol != "grpc" && config.Dialer != nil {
+		return errors.New("a custom dialer requires the grpc protocol")
+	}
+	if config.Protocol != "grpc" && (config.PredictRequest != nil || config.ExpectSignature != nil) {
+		return errors.New("the smoke test and signature check require the grpc protocol")
+	}
+	return nil
+}
+
+// Check the models, and then the signature and smoke test when configured
+func (p *Prober) Check(ctx context.Context) *Result {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	start := time.Now()
+	p.timing = phaseTiming{}
+
+	// keep polling until the models are ready, or check once
+	var results []ModelResult
+	var retval int
+	var cause error
+	if p.config.Wait {
+		results, retval, cause = p.waitForModels(ctx)
+	} else {
+		results, retval, cause = p.checkOnce(ctx, p.config.Models, p.config.MinAvailable)
+	}
+
+	// compare the signatures of the first model once it is AVAILABLE
+	target := p.config.Models[0]
+	failed := ""
+	var diffs []SignatureDiff
+	if retval == 0 && p.config.ExpectSignature != nil {
+		ctxMetadata, cancelMetadata := context.WithTimeout(ctx, p.config.RPCTimeout)
+		client := tfproto.NewPredictionServiceClient(p.c.conn)
+		retval, diffs, cause = p.runSignatureCheck(ctxMetadata, client, target, p.config.ExpectSignature)
+		cancelMetadata()
+		if retval != 0 {
+			failed = target.String()
+		}
+	}
+
+	// send an inference request to the first model once it is AVAILABLE
+	if retval == 0 && p.config.PredictRequest != nil {
+		ctxPredict, cancelPredict := context.WithTimeout(ctx, p.config.RPCTimeout)
+		client := tfproto.NewPredictionServiceClient(p.c.conn)
+		smoke := &smokeTest{
+			request:   p.config.PredictRequest,
+			expect:    p.config.PredictExpect,
+			tolerance: p.config.PredictTolerance,
+		}
+		retval = p.runSmokeTest(ctxPredict, client, target, smoke)
+		cancelPredict()
+		if retval != 0 {
+			failed = target.String()
+		}
+	}
+
+	result := newResult(results, retval, failed, cause, time.Since(start))
+	result.SignatureDiffs = diffs
+	result.DialLatency = p.timing.dial
+	result.RPCLatency = p.timing.rpc
+	return result
+}
+
+// CheckModels checks the given models once over the same connection, all
+// of which must be AVAILABLE. The config does not need to list them, and the
+// signature check and smoke test are not run.
+func (p *Prober) CheckModels(ctx context.Context, models []Model) *Result {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	start := time.Now()
+	p.timing = phaseTiming{}
+
+	results, retval, cause := p.checkOnce(ctx, models, 0)
+	result := newResult(results, retval, "", cause, time.Since(start))
+	result.DialLatency = p.timing.dial
+	result.RPCLatency = p.timing.rpc
+	return result
+}
+
+// Connect when needed, and call and check model status for the models
+func (p *Prober) checkOnce(ctx context.Context, models []Model, minAvailable int) ([]ModelResult, int, error) {
+
+	// connect over the chosen transport
+	if p.fetch == nil {
+		fetch, retval, err := p.connect(ctx)
+		if retval != 0 {
+			return nil, retval, err
+		}
+		p.fetch = fetch
+	}
+
+	// set a timeout on the rpc
+	ctxRpc, cancelRpc := context.WithTimeout(ctx, p.config.RPCTimeout)
+	defer cancelRpc()
+
+	// call and check model status for all models
+	results := p.fetchModelStatuses(ctxRpc, models)
+	retval := p.checkModelResults(results, minAvailable)
+
+	// connect again on the next check
+	if retval != 0 && connectionFailed(results) {
+		p.disconnect()
+	}
+	return results, retval, nil
+}
+
+// Close the connection, if any
+func (p *Prober) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.disconnect()
+	return nil
+}
+
+func (p *Prober) disconnect() {
+	p.c.close()
+	p.fetch = nil
+}
+
+// Build the result. Unless given, the failed model and the cause of the
+// failure are taken from the first model with the failing return value.
+func newResult(results []ModelResult, retval int, model string, cause error, latency time.Duration) *Result {
+	result := &Result{
+		ExitCode: retval,
+		Category: CategoryOf(retval),
+		Models:   results,
+		Latency:  latency,
+	}
+	if len(results) > 0 {
+		result.State = results[0].State
+		result.Version = results[0].Version
+	}
+	if retval == 0 {
+		return result
+	}
+
+	for _, r := range results {
+		if model == "" && r.ExitCode == retval {
+			model = r.Model.String()
+			if cause == nil {
+				cause = r.Err
+			}
+			if cause == nil && r.ErrorMessage != "" {
+				cause = errors.New(r.ErrorMessage)
+			}
+			break
+		}
+	}
+	result.Err = newError(retval, model, cause)
+	return result
+}
@@ -0,0 +1,170 @@
+//
+// Copyright 2020 Cody Collier <cody@telnet.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package probe
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/codycollier/tfs-model-status-probe/tfproto/tfproto"
+)
+
+// Return a prober for calling the check methods directly, logging as the
+// command line probe does
+func testProber() *Prober {
+	return &Prober{log: log.New(os.Stderr, "", log.LstdFlags)}
+}
+
+// The return value of a check which also returns the version checked
+func retvalOf(retval int, version int64) int {
+	return retval
+}
+
+func TestNewDefaults(t *testing.T) {
+	p, err := New(Config{})
+	assert.Nil(t, err)
+	assert.Equal(t, "localhost:9000", p.config.Addr)
+	assert.Equal(t, "grpc", p.config.Protocol)
+	assert.Equal(t, []Model{{Name: "default"}}, p.config.Models)
+	assert.Equal(t, time.Second*3, p.config.ConnectTimeout)
+	assert.Equal(t, time.Second*10, p.config.RPCTimeout)
+}
+
+func TestNewInvalidConfig(t *testing.T) {
+	configs := []Config{
+		{Protocol: "carrier-pigeon"},
+		{VersionFilter: "both"},
+		{Models: []Model{{Name: "half_plus_two"}}, MinAvailable: 2},
+		{Models: []Model{{Name: "half_plus_two", Version: 1, Label: "stable"}}},
+		{Protocol: "rest", PredictRequest: &tfproto.PredictRequest{}},
+		{MinVersion: 5, MaxVersion: 3},
+		{MaxAvailableVersions: -1},
+		{ConnectTimeout: -time.Second},
+		{RPCTimeout: -time.Second},
+		{WaitTimeout: -time.Second},
+		{PollInterval: -time.Second},
+		{MaxPollInterval: -time.Second},
+	}
+	for _, config := range configs {
+		_, err := New(config)
+		var probeErr *Error
+		assert.True(t, errors.As(err, &probeErr))
+		assert.Equal(t, 1, probeErr.ExitCode)
+		assert.Equal(t, CategoryConfig, probeErr.Category)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	server := restServer(map[string]string{
+		"/v1/models/half_plus_two": `{"model_version_status": [{"version": "123", "state": "AVAILABLE"}]}`,
+		"/v1/models/resnet":        `{"model_version_status": [{"version": "7", "state": "LOADING"}]}`,
+	})
+	defer server.Close()
+	addr := strings.TrimPrefix(server.URL, "http://")
+
+	p, err := New(Config{Addr: addr, Protocol: "rest", Models: []Model{{Name: "half_plus_two"}}})
+	assert.Nil(t, err)
+	defer p.Close()
+	result := p.Check(context.Background())
+	assert.Equal(t, 0, result.ExitCode)
+	assert.Equal(t, CategoryNone, result.Category)
+	assert.Equal(t, tfproto.ModelVersionStatus_AVAILABLE, result.State)
+	assert.Equal(t, int64(123), result.Version)
+	assert.Nil(t, result.Err)
+	assert.True(t, result.Latency > 0)
+	assert.True(t, result.RPCLatency > 0 && result.RPCLatency <= result.Latency)
+
+	// The first failing model is reported
+	p, err = New(Config{Addr: addr, Protocol: "rest", Models: []Model{{Name: "half_plus_two"}, {Name: "resnet"}, {Name: "missing"}}})
+	assert.Nil(t, err)
+	defer p.Close()
+	result = p.Check(context.Background())
+	assert.Equal(t, 32, result.ExitCode)
+	assert.Equal(t, CategoryNotReady, result.Category)
+	assert.Len(t, result.Models, 3)
+	assert.Equal(t, 32, result.Models[1].ExitCode)
+	assert.Equal(t, 10, result.Models[2].ExitCode)
+	var probeErr *Error
+	assert.True(t, errors.As(result.Err, &probeErr))
+	assert.Equal(t, "resnet", probeErr.Model)
+	assert.Equal(t, "model resnet: servable state is LOADING", result.Err.Error())
+	assert.Equal(t, codes.NotFound, status.Code(result.Models[2].Err))
+}
+
+func TestCheckConnectionRefused(t *testing.T) {
+	server := restServer(map[string]string{})
+	addr := strings.TrimPrefix(server.URL, "http://")
+	server.Close()
+
+	p, err := New(Config{Addr: addr, Protocol: "rest"})
+	assert.Nil(t, err)
+	result := p.Check(context.Background())
+	assert.Equal(t, 2, result.ExitCode)
+	assert.Equal(t, CategoryConnection, result.Category)
+	var transportErr *restTransportError
+	assert.True(t, errors.As(result.Err, &transportErr))
+}
+
+func TestCategoryOf(t *testing.T) {
+	assert.Equal(t, CategoryNone, CategoryOf(0))
+	assert.Equal(t, CategoryConnection, CategoryOf(5))
+	assert.Equal(t, CategoryNotFound, CategoryOf(13))
+	assert.Equal(t, CategoryTimeout, CategoryOf(14))
+	assert.Equal(t, CategoryConstraint, CategoryOf(28))
+	assert.Equal(t, CategoryNotReady, CategoryOf(34))
+	assert.Equal(t, CategoryInference, CategoryOf(43))
+	assert.Equal(t, CategorySignature, CategoryOf(51))
+	assert.Equal(t, CategoryLoadFailed, CategoryOf(65))
+	assert.Equal(t, CategoryNotReady, CategoryOf(100))
+}
+
+func TestOutcomeOf(t *testing.T) {
+	assert.Equal(t, "available", OutcomeOf(0))
+	assert.Equal(t, "dial", OutcomeOf(2))
+	assert.Equal(t, "state_unloading", OutcomeOf(33))
+	assert.Equal(t, "load_failed", OutcomeOf(65))
+	assert.Equal(t, "", OutcomeOf(99))
+
+	outcomes := Outcomes()
+	assert.Len(t, outcomes, len(exitCodeOutcome)+1)
+	assert.Equal(t, "available", outcomes[0])
+	assert.Equal(t, "unexpected_state", outcomes[len(outcomes)-1])
+}
+
+func TestCheckLoadFailed(t *testing.T) {
+	server := restServer(map[string]string{
+		"/v1/models/half_plus_two": `{"model_version_status": [{"version": "123", "state": "END", "status": {"error_code": "INVALID_ARGUMENT", "error_message": "Invalid SavedModel"}}]}`,
+	})
+	defer server.Close()
+
+	p, err := New(Config{Addr: strings.TrimPrefix(server.URL, "http://"), Protocol: "rest", Models: []Model{{Name: "half_plus_two"}}})
+	assert.Nil(t, err)
+	result := p.Check(context.Background())
+	assert.Equal(t, 63, result.ExitCode)
+	assert.Equal(t, CategoryLoadFailed, result.Category)
+	assert.Equal(t, tfproto.Code_INVALID_ARGUMENT, result.Models[0].ErrorCode)
+	assert.Equal(t, "model half_plus_two: servable state is END with error INVALID_ARGUMENT: Invalid SavedModel", result.Err.Error())
+}
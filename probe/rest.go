@@ -0,0 +1,158 @@
+//
+// Copyright 2020 Cody Collier <cody@telnet.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package probe
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/codycollier/tfs-model-status-probe/tfproto/tfproto"
+)
+
+// restTransportError is returned when the http request could not be made at
+// all, as opposed to the server answering with an error
+type restTransportError struct {
+	err error
+}
+
+func (e *restTransportError) Error() string {
+	return e.err.Error()
+}
+
+func (e *restTransportError) Unwrap() error {
+	return e.err
+}
+
+// The grpc code of a failure to reach the server, as with grpc transport
+func (e *restTransportError) GRPCStatus() *status.Status {
+	return status.New(codes.Unavailable, e.err.Error())
+}
+
+// Map http status codes from the TFS REST api back to the grpc codes used by
+// the ModelService, so both transports share the same error handling
+var httpStatusCodes = map[int]codes.Code{
+	http.StatusBadRequest:          codes.InvalidArgument,
+	http.StatusUnauthorized:        codes.Unauthenticated,
+	http.StatusForbidden:           codes.PermissionDenied,
+	http.StatusNotFound:            codes.NotFound,
+	http.StatusConflict:            codes.AlreadyExists,
+	http.StatusTooManyRequests:     codes.ResourceExhausted,
+	http.StatusNotImplemented:      codes.Unimplemented,
+	http.StatusServiceUnavailable:  codes.Unavailable,
+	http.StatusGatewayTimeout:      codes.DeadlineExceeded,
+	http.StatusInternalServerError: codes.Internal,
+}
+
+// Build an http client for the TFS REST api
+func newRESTClient(connectTimeout time.Duration, tlsConfig *tls.Config) *http.Client {
+	return &http.Client{
+		Transport: &http.Transport{
+			DialContext:         (&net.Dialer{Timeout: connectTimeout}).DialContext,
+			TLSClientConfig:     tlsConfig,
+			TLSHandshakeTimeout: connectTimeout,
+		},
+	}
+}
+
+// Build the model status url for the TFS REST api
+func modelStatusURL(addr string, useTLS bool, target Model) string {
+	scheme := "http"
+	if useTLS {
+		scheme = "https"
+	}
+	path := "/v1/models/" + url.PathEscape(target.Name)
+	if target.Version != 0 {
+		path += fmt.Sprintf("/versions/%d", target.Version)
+	} else if target.Label != "" {
+		path += "/labels/" + url.PathEscape(target.Label)
+	}
+	return scheme + "://" + addr + path
+}
+
+// Call GET /v1/models/{name}[/versions/{v}|/labels/{l}] on the TFS REST api and return response
+func callModelStatusREST(ctx context.Context, client *http.Client, statusURL string) (*tfproto.GetModelStatusResponse, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, statusURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	httpResponse, err := client.Do(request)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+		return nil, &restTransportError{err: err}
+	}
+	defer httpResponse.Body.Close()
+
+	body, err := ioutil.ReadAll(httpResponse.Body)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "reading response: %v", err)
+	}
+
+	// TFS reports errors as {"error": "..."} with a matching http status
+	if httpResponse.StatusCode != http.StatusOK {
+		var errorBody struct {
+			Error string `json:"error"`
+		}
+		message := string(body)
+		if json.Unmarshal(body, &errorBody) == nil && errorBody.Error != "" {
+			message = errorBody.Error
+		}
+		code, ok := httpStatusCodes[httpResponse.StatusCode]
+		if !ok {
+			code = codes.Unknown
+		}
+		return nil, status.Error(code, message)
+	}
+
+	// The json body is the proto3 json mapping of GetModelStatusResponse
+	response := &tfproto.GetModelStatusResponse{}
+	unmarshal := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err := unmarshal.Unmarshal(body, response); err != nil {
+		return nil, status.Errorf(codes.Internal, "decoding response: %v", err)
+	}
+	return response, nil
+}
+
+// Map a failure to reach the REST api to a return value, mirroring the dial
+// and tls handshake failures of the grpc transport
+func restTransportErrorCode(err error) int {
+	var opErr *net.OpError
+	var recordErr tls.RecordHeaderError
+	switch {
+	case tlsErrorCode(err) == ExitTLSCertificateInvalid:
+		return ExitTLSCertificateInvalid
+	case errors.As(err, &recordErr):
+		return ExitTLSHandshakeFailed
+	case errors.As(err, &opErr) && opErr.Op == "remote error":
+		return ExitTLSHandshakeFailed
+	default:
+		return ExitDialFailed
+	}
+}
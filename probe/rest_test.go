@@ -0,0 +1,104 @@
+//
+// Copyright 2020 Cody Collier <cody@telnet.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package probe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/codycollier/tfs-model-status-probe/tfproto/tfproto"
+)
+
+// Serve canned TFS REST responses keyed by path
+func restServer(responses map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintf(w, `{"error": "Could not find any versions of model %v"}`, strings.TrimPrefix(r.URL.Path, "/v1/models/"))
+			return
+		}
+		fmt.Fprint(w, body)
+	}))
+}
+
+func TestRESTModelStatus(t *testing.T) {
+	server := restServer(map[string]string{
+		"/v1/models/half_plus_two": `{
+			"model_version_status": [
+				{"version": "123", "state": "AVAILABLE", "status": {"error_code": "OK", "error_message": ""}},
+				{"version": "122", "state": "END", "status": {"error_code": "OK", "error_message": ""}}
+			]
+		}`,
+	})
+	defer server.Close()
+
+	client := newRESTClient(time.Second, nil)
+	addr := strings.TrimPrefix(server.URL, "http://")
+	response, err := callModelStatusREST(context.Background(), client, modelStatusURL(addr, false, Model{Name: "half_plus_two"}))
+	assert.Nil(t, err)
+	assert.Len(t, response.ModelVersionStatus, 2)
+	assert.Equal(t, int64(123), response.ModelVersionStatus[0].Version)
+	assert.Equal(t, tfproto.ModelVersionStatus_AVAILABLE, response.ModelVersionStatus[0].State)
+	assert.Equal(t, tfproto.Code_OK, response.ModelVersionStatus[0].Status.ErrorCode)
+
+	// Same exit codes as the grpc transport
+	assert.Equal(t, 0, testProber().checkServableResponse(response, 0))
+	assert.Equal(t, 34, testProber().checkServableResponse(response, 122))
+}
+
+func TestRESTModelNotFound(t *testing.T) {
+	server := restServer(map[string]string{})
+	defer server.Close()
+
+	client := newRESTClient(time.Second, nil)
+	addr := strings.TrimPrefix(server.URL, "http://")
+	_, err := callModelStatusREST(context.Background(), client, modelStatusURL(addr, false, Model{Name: "no-such-model"}))
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Contains(t, status.Convert(err).Message(), "no-such-model")
+}
+
+func TestRESTConnectionRefused(t *testing.T) {
+	server := restServer(map[string]string{})
+	addr := strings.TrimPrefix(server.URL, "http://")
+	server.Close()
+
+	client := newRESTClient(time.Second, nil)
+	_, err := callModelStatusREST(context.Background(), client, modelStatusURL(addr, false, Model{Name: "half_plus_two"}))
+	var transportErr *restTransportError
+	assert.True(t, errors.As(err, &transportErr))
+	assert.Equal(t, 2, restTransportErrorCode(err))
+}
+
+func TestModelStatusURL(t *testing.T) {
+	target := Model{Name: "half_plus_two"}
+	assert.Equal(t, "http://localhost:8501/v1/models/half_plus_two", modelStatusURL("localhost:8501", false, target))
+	target = Model{Name: "half_plus_two", Version: 123}
+	assert.Equal(t, "https://localhost:8501/v1/models/half_plus_two/versions/123", modelStatusURL("localhost:8501", true, target))
+	target = Model{Name: "half_plus_two", Label: "canary"}
+	assert.Equal(t, "http://localhost:8501/v1/models/half_plus_two/labels/canary", modelStatusURL("localhost:8501", false, target))
+}
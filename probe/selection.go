@@ -0,0 +1,89 @@
+//
+// Copyright 2020 Cody Collier <cody@telnet.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package probe
+
+import (
+	"sort"
+
+	"github.com/codycollier/tfs-model-status-probe/tfproto/tfproto"
+)
+
+// Version selection strategies, used when no version is requested. The
+// selection never depends on the order of the versions in the response.
+const (
+	// The highest AVAILABLE version, or else the highest version
+	SelectAnyAvailable = "any-available"
+	// The highest version, whatever its state
+	SelectHighest = "highest"
+	// The highest version which is not AVAILABLE, so that every version must
+	// be AVAILABLE, or else the highest version
+	SelectAllAvailable = "all-available"
+	// The version furthest along towards serving, the highest on a tie
+	SelectMostAdvanced = "most-advanced"
+)
+
+// Rank of the states for SelectMostAdvanced, from loaded to gone
+var stateRank = map[tfproto.ModelVersionStatus_State]int{
+	tfproto.ModelVersionStatus_AVAILABLE: 5,
+	tfproto.ModelVersionStatus_LOADING:   4,
+	tfproto.ModelVersionStatus_START:     3,
+	tfproto.ModelVersionStatus_UNLOADING: 2,
+	tfproto.ModelVersionStatus_END:       1,
+}
+
+func isSelection(strategy string) bool {
+	switch strategy {
+	case SelectAnyAvailable, SelectHighest, SelectAllAvailable, SelectMostAdvanced:
+		return true
+	}
+	return false
+}
+
+// Select a version of a non-empty response by the strategy, defaulting to
+// SelectAnyAvailable
+func selectVersion(versions []*tfproto.ModelVersionStatus, strategy string) *tfproto.ModelVersionStatus {
+	sorted := make([]*tfproto.ModelVersionStatus, len(versions))
+	copy(sorted, versions)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Version > sorted[j].Version })
+
+	switch strategy {
+	case SelectHighest:
+		return sorted[0]
+	case SelectAllAvailable:
+		for _, res := range sorted {
+			if res.State != tfproto.ModelVersionStatus_AVAILABLE {
+				return res
+			}
+		}
+		return sorted[0]
+	case SelectMostAdvanced:
+		selected := sorted[0]
+		for _, res := range sorted[1:] {
+			if stateRank[res.State] > stateRank[selected.State] {
+				selected = res
+			}
+		}
+		return selected
+	default:
+		for _, res := range sorted {
+			if res.State == tfproto.ModelVersionStatus_AVAILABLE {
+				return res
+			}
+		}
+		return sorted[0]
+	}
+}
@@ -0,0 +1,137 @@
+//
+// Copyright 2020 Cody Collier <cody@telnet.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package probe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/codycollier/tfs-model-status-probe/tfproto/tfproto"
+)
+
+// Every ordering of the versions, as TFS does not promise one
+func permutations(versions []*tfproto.ModelVersionStatus) [][]*tfproto.ModelVersionStatus {
+	if len(versions) <= 1 {
+		return [][]*tfproto.ModelVersionStatus{versions}
+	}
+	var result [][]*tfproto.ModelVersionStatus
+	for i := range versions {
+		rest := make([]*tfproto.ModelVersionStatus, 0, len(versions)-1)
+		rest = append(rest, versions[:i]...)
+		rest = append(rest, versions[i+1:]...)
+		for _, p := range permutations(rest) {
+			result = append(result, append([]*tfproto.ModelVersionStatus{versions[i]}, p...))
+		}
+	}
+	return result
+}
+
+func TestSelectVersion(t *testing.T) {
+	cases := []struct {
+		versions map[int64]tfproto.ModelVersionStatus_State
+		expected map[string]int64
+	}{
+		{
+			// a new version is loading while the previous one serves
+			versions: map[int64]tfproto.ModelVersionStatus_State{
+				1: tfproto.ModelVersionStatus_END,
+				2: tfproto.ModelVersionStatus_AVAILABLE,
+				3: tfproto.ModelVersionStatus_LOADING,
+			},
+			expected: map[string]int64{
+				"":                 2,
+				SelectAnyAvailable: 2,
+				SelectHighest:      3,
+				SelectAllAvailable: 3,
+				SelectMostAdvanced: 2,
+			},
+		},
+		{
+			// nothing AVAILABLE
+			versions: map[int64]tfproto.ModelVersionStatus_State{
+				4: tfproto.ModelVersionStatus_LOADING,
+				5: tfproto.ModelVersionStatus_START,
+				6: tfproto.ModelVersionStatus_END,
+			},
+			expected: map[string]int64{
+				SelectAnyAvailable: 6,
+				SelectHighest:      6,
+				SelectAllAvailable: 6,
+				SelectMostAdvanced: 4,
+			},
+		},
+		{
+			// every version AVAILABLE but an old one unloading
+			versions: map[int64]tfproto.ModelVersionStatus_State{
+				7: tfproto.ModelVersionStatus_UNLOADING,
+				8: tfproto.ModelVersionStatus_AVAILABLE,
+				9: tfproto.ModelVersionStatus_AVAILABLE,
+			},
+			expected: map[string]int64{
+				SelectAnyAvailable: 9,
+				SelectHighest:      9,
+				SelectAllAvailable: 7,
+				SelectMostAdvanced: 9,
+			},
+		},
+	}
+	for _, c := range cases {
+		var versions []*tfproto.ModelVersionStatus
+		for version, state := range c.versions {
+			versions = append(versions, &tfproto.ModelVersionStatus{Version: version, State: state})
+		}
+		for _, shuffled := range permutations(versions) {
+			for strategy, expected := range c.expected {
+				assert.Equal(t, expected, selectVersion(shuffled, strategy).Version, strategy)
+			}
+		}
+	}
+}
+
+func TestSelectionExitCodes(t *testing.T) {
+	response := &tfproto.GetModelStatusResponse{
+		ModelVersionStatus: []*tfproto.ModelVersionStatus{
+			{Version: 3, State: tfproto.ModelVersionStatus_LOADING},
+			{Version: 2, State: tfproto.ModelVersionStatus_AVAILABLE},
+		},
+	}
+	expected := map[string]int{
+		SelectAnyAvailable: 0,
+		SelectHighest:      32,
+		SelectAllAvailable: 32,
+		SelectMostAdvanced: 0,
+	}
+	for strategy, code := range expected {
+		p := testProber()
+		p.config.Selection = strategy
+		assert.Equal(t, code, p.checkServableResponse(response, 0), strategy)
+
+		// the selected version is reported
+		results := []ModelResult{{Model: Model{Name: "half_plus_two"}, Response: response}}
+		p.checkModelResults(results, 0)
+		assert.Equal(t, code, results[0].ExitCode)
+		if code == 0 {
+			assert.Equal(t, int64(2), results[0].Version)
+		} else {
+			assert.Equal(t, int64(3), results[0].Version)
+		}
+	}
+
+	_, err := New(Config{Selection: "newest"})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,191 @@
+//
+// Copyright 2020 Cody Collier <cody@telnet.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package probe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/codycollier/tfs-model-status-probe/tfproto/tfproto"
+)
+
+// SignatureDiff is a single difference between the expected and the live
+// signature_def of a model
+type SignatureDiff struct {
+	Signature  string
+	Kind       string // signature, input, output or method
+	Key        string
+	Change     string // missing, extra, dtype, shape, name or method
+	Expected   string
+	Actual     string
+	Compatible bool
+}
+
+func (d SignatureDiff) String() string {
+	severity := "incompatible"
+	if d.Compatible {
+		severity = "compatible"
+	}
+	s := fmt.Sprintf("%v: signature %q", severity, d.Signature)
+	if d.Key != "" {
+		s += fmt.Sprintf(" %v %q", d.Kind, d.Key)
+	}
+	s += " " + d.Change
+	if d.Expected != "" || d.Actual != "" {
+		s += fmt.Sprintf(" (expected %v, actual %v)", d.Expected, d.Actual)
+	}
+	return s
+}
+
+// Call PredictionService.GetModelMetadata() and return the signature_def map
+func callSignatureDef(ctx context.Context, client tfproto.PredictionServiceClient, target Model) (*tfproto.SignatureDefMap, error) {
+	request := &tfproto.GetModelMetadataRequest{
+		ModelSpec: &tfproto.ModelSpec{
+			Name: target.Name,
+		},
+		MetadataField: []string{"signature_def"},
+	}
+	if target.Version != 0 {
+		request.ModelSpec.VersionChoice = &tfproto.ModelSpec_Version{Version: wrapperspb.Int64(target.Version)}
+	} else if target.Label != "" {
+		request.ModelSpec.VersionChoice = &tfproto.ModelSpec_VersionLabel{VersionLabel: target.Label}
+	}
+	response, err := client.GetModelMetadata(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata, ok := response.Metadata["signature_def"]
+	if !ok {
+		return nil, fmt.Errorf("response has no signature_def metadata")
+	}
+	signatures := &tfproto.SignatureDefMap{}
+	if err := proto.Unmarshal(metadata.Value, signatures); err != nil {
+		return nil, fmt.Errorf("decoding signature_def metadata: %v", err)
+	}
+	return signatures, nil
+}
+
+// Fetch the live signatures of a model, compare them against the expected
+// signatures and map the outcome to a return value. The differences are
+// returned, with the error of the failed call or the first incompatible one.
+func (p *Prober) runSignatureCheck(ctx context.Context, client tfproto.PredictionServiceClient, target Model, expect *tfproto.SignatureDefMap) (int, []SignatureDiff, error) {
+	p.log.Printf("Signature check: model %v\n", target.Name)
+	actual, err := callSignatureDef(ctx, client, target)
+	if err != nil {
+		p.log.Printf("Error calling model metadata: %v\n", err)
+		return ExitMetadataFailed, nil, err
+	}
+	diffs := diffSignatureDefs(expect, actual)
+	retval, err := p.checkSignatureDiffs(diffs)
+	return retval, diffs, err
+}
+
+// Log each difference and return 50 when any is incompatible, with the first
+// incompatible difference as the error
+func (p *Prober) checkSignatureDiffs(diffs []SignatureDiff) (int, error) {
+	var incompatible error
+	for _, d := range diffs {
+		p.log.Printf("Signature diff: %v\n", d)
+		if !d.Compatible && incompatible == nil {
+			incompatible = errors.New(d.String())
+		}
+	}
+	if incompatible != nil {
+		return ExitSignatureIncompatible, incompatible
+	}
+	p.log.Printf("Signature check succeeded with %v compatible differences\n", len(diffs))
+	return 0, nil
+}
+
+// Compare the expected signatures against the live ones. Clients written
+// against the expected signatures keep working when outputs or signatures
+// are added, but not when anything they send or read is removed or changed,
+// or when a new input must be fed.
+func diffSignatureDefs(expect, actual *tfproto.SignatureDefMap) []SignatureDiff {
+	var diffs []SignatureDiff
+	for _, name := range sortedKeys(expect.GetSignatureDef()) {
+		expected := expect.SignatureDef[name]
+		live, ok := actual.GetSignatureDef()[name]
+		if !ok {
+			diffs = append(diffs, SignatureDiff{Signature: name, Kind: "signature", Change: "missing"})
+			continue
+		}
+		if expected.MethodName != "" && expected.MethodName != live.MethodName {
+			diffs = append(diffs, SignatureDiff{Signature: name, Kind: "method", Change: "method", Expected: expected.MethodName, Actual: live.MethodName})
+		}
+		diffs = append(diffs, diffTensorInfos(name, "input", expected.Inputs, live.Inputs, false)...)
+		diffs = append(diffs, diffTensorInfos(name, "output", expected.Outputs, live.Outputs, true)...)
+	}
+	for _, name := range sortedKeys(actual.GetSignatureDef()) {
+		if _, ok := expect.GetSignatureDef()[name]; !ok {
+			diffs = append(diffs, SignatureDiff{Signature: name, Kind: "signature", Change: "extra", Compatible: true})
+		}
+	}
+	return diffs
+}
+
+// Compare the inputs or outputs of a signature. The dtype and shape must
+// match, with an expected dim size of -1 matching any size. Tensor names
+// are internal to the graph, so a change is compatible.
+func diffTensorInfos(signature, kind string, expected, actual map[string]*tfproto.TensorInfo, extraCompatible bool) []SignatureDiff {
+	var diffs []SignatureDiff
+	for _, key := range sortedKeys(expected) {
+		e := expected[key]
+		a, ok := actual[key]
+		if !ok {
+			diffs = append(diffs, SignatureDiff{Signature: signature, Kind: kind, Key: key, Change: "missing"})
+			continue
+		}
+		if e.Dtype != a.Dtype {
+			diffs = append(diffs, SignatureDiff{Signature: signature, Kind: kind, Key: key, Change: "dtype", Expected: e.Dtype.String(), Actual: a.Dtype.String()})
+		}
+		if e.TensorShape != nil && !shapeMatches(a.TensorShape, e.TensorShape) {
+			diffs = append(diffs, SignatureDiff{Signature: signature, Kind: kind, Key: key, Change: "shape", Expected: shapeString(e.TensorShape), Actual: shapeString(a.TensorShape)})
+		}
+		if e.GetName() != "" && e.GetName() != a.GetName() {
+			diffs = append(diffs, SignatureDiff{Signature: signature, Kind: kind, Key: key, Change: "name", Expected: e.GetName(), Actual: a.GetName(), Compatible: true})
+		}
+	}
+	for _, key := range sortedKeys(actual) {
+		if _, ok := expected[key]; !ok {
+			diffs = append(diffs, SignatureDiff{Signature: signature, Kind: kind, Key: key, Change: "extra", Compatible: extraCompatible})
+		}
+	}
+	return diffs
+}
+
+func sortedKeys(m interface{}) []string {
+	var keys []string
+	switch m := m.(type) {
+	case map[string]*tfproto.SignatureDef:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	case map[string]*tfproto.TensorInfo:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
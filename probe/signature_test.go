@@ -0,0 +1,124 @@
+//
+// Copyright 2020 Cody Collier <cody@telnet.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package probe
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/codycollier/tfs-model-status-probe/tfproto/tfproto"
+)
+
+func tensorInfo(name string, dtype tfproto.DataType, dims ...int64) *tfproto.TensorInfo {
+	shape := &tfproto.TensorShapeProto{}
+	for _, size := range dims {
+		shape.Dim = append(shape.Dim, &tfproto.TensorShapeProto_Dim{Size: size})
+	}
+	return &tfproto.TensorInfo{
+		Encoding:    &tfproto.TensorInfo_Name{Name: name},
+		Dtype:       dtype,
+		TensorShape: shape,
+	}
+}
+
+func halfPlusTwoSignatures() *tfproto.SignatureDefMap {
+	return &tfproto.SignatureDefMap{
+		SignatureDef: map[string]*tfproto.SignatureDef{
+			"serving_default": {
+				Inputs:     map[string]*tfproto.TensorInfo{"x": tensorInfo("x:0", tfproto.DataType_DT_FLOAT, -1, 1)},
+				Outputs:    map[string]*tfproto.TensorInfo{"y": tensorInfo("y:0", tfproto.DataType_DT_FLOAT, -1, 1)},
+				MethodName: "tensorflow/serving/predict",
+			},
+		},
+	}
+}
+
+func TestDiffSignatureDefs(t *testing.T) {
+	expect := halfPlusTwoSignatures()
+	actual := halfPlusTwoSignatures()
+	assert.Empty(t, diffSignatureDefs(expect, actual))
+
+	// Compatible: renamed tensor, extra output, extra signature
+	live := actual.SignatureDef["serving_default"]
+	live.Outputs["y"] = tensorInfo("y_1:0", tfproto.DataType_DT_FLOAT, 8, 1)
+	live.Outputs["z"] = tensorInfo("z:0", tfproto.DataType_DT_FLOAT, -1, 1)
+	actual.SignatureDef["regress_x_to_y"] = &tfproto.SignatureDef{}
+	diffs := diffSignatureDefs(expect, actual)
+	assert.Equal(t, []SignatureDiff{
+		{Signature: "serving_default", Kind: "output", Key: "y", Change: "name", Expected: "y:0", Actual: "y_1:0", Compatible: true},
+		{Signature: "serving_default", Kind: "output", Key: "z", Change: "extra", Compatible: true},
+		{Signature: "regress_x_to_y", Kind: "signature", Change: "extra", Compatible: true},
+	}, diffs)
+	retval, err := testProber().checkSignatureDiffs(diffs)
+	assert.Equal(t, 0, retval)
+	assert.Nil(t, err)
+
+	// Incompatible: renamed input, changed dtype and shape
+	delete(live.Inputs, "x")
+	live.Inputs["inputs"] = tensorInfo("x:0", tfproto.DataType_DT_FLOAT, -1, 1)
+	live.Outputs["y"] = tensorInfo("y:0", tfproto.DataType_DT_DOUBLE, -1, 2)
+	diffs = diffSignatureDefs(expect, actual)
+	assert.Equal(t, []SignatureDiff{
+		{Signature: "serving_default", Kind: "input", Key: "x", Change: "missing"},
+		{Signature: "serving_default", Kind: "input", Key: "inputs", Change: "extra"},
+		{Signature: "serving_default", Kind: "output", Key: "y", Change: "dtype", Expected: "DT_FLOAT", Actual: "DT_DOUBLE"},
+		{Signature: "serving_default", Kind: "output", Key: "y", Change: "shape", Expected: "[-1,1]", Actual: "[-1,2]"},
+		{Signature: "serving_default", Kind: "output", Key: "z", Change: "extra", Compatible: true},
+		{Signature: "regress_x_to_y", Kind: "signature", Change: "extra", Compatible: true},
+	}, diffs)
+	retval, err = testProber().checkSignatureDiffs(diffs)
+	assert.Equal(t, 50, retval)
+	assert.EqualError(t, err, `incompatible: signature "serving_default" input "x" missing`)
+
+	// Missing signature
+	delete(actual.SignatureDef, "serving_default")
+	diffs = diffSignatureDefs(expect, actual)
+	assert.Equal(t, SignatureDiff{Signature: "serving_default", Kind: "signature", Change: "missing"}, diffs[0])
+	assert.Equal(t, `incompatible: signature "serving_default" missing`, diffs[0].String())
+}
+
+func TestRunSignatureCheck(t *testing.T) {
+	target := Model{Name: "half_plus_two"}
+	expect := halfPlusTwoSignatures()
+
+	value, err := proto.Marshal(halfPlusTwoSignatures())
+	assert.Nil(t, err)
+	client := &predictionServiceStub{metadata: &tfproto.GetModelMetadataResponse{
+		Metadata: map[string]*anypb.Any{
+			"signature_def": {TypeUrl: "type.googleapis.com/tensorflow.serving.SignatureDefMap", Value: value},
+		},
+	}}
+	retval, diffs, err := testProber().runSignatureCheck(context.Background(), client, target, expect)
+	assert.Equal(t, 0, retval)
+	assert.Empty(t, diffs)
+	assert.Nil(t, err)
+
+	client.metadata.Metadata = nil
+	retval, _, err = testProber().runSignatureCheck(context.Background(), client, target, expect)
+	assert.Equal(t, 51, retval)
+	assert.NotNil(t, err)
+
+	client = &predictionServiceStub{err: status.Error(codes.Unimplemented, "")}
+	retval, _, _ = testProber().runSignatureCheck(context.Background(), client, target, expect)
+	assert.Equal(t, 51, retval)
+}
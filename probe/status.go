@@ -0,0 +1,127 @@
+//
+// Copyright 2020 Cody Collier <cody@telnet.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package probe
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/codycollier/tfs-model-status-probe/tfproto/tfproto"
+)
+
+// Call ModelService.GetModelStatus() and return response
+func callModelStatus(ctx context.Context, client tfproto.ModelServiceClient, target Model) (*tfproto.GetModelStatusResponse, error) {
+	request := &tfproto.GetModelStatusRequest{
+		ModelSpec: &tfproto.ModelSpec{
+			Name: target.Name,
+		},
+	}
+	if target.Version != 0 {
+		request.ModelSpec.VersionChoice = &tfproto.ModelSpec_Version{Version: wrapperspb.Int64(target.Version)}
+	} else if target.Label != "" {
+		request.ModelSpec.VersionChoice = &tfproto.ModelSpec_VersionLabel{VersionLabel: target.Label}
+	}
+	response, err := client.GetModelStatus(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// Get the status for the noted version. If no version, select one by the
+// strategy. Returns nil when there is no matching version.
+func selectServable(response *tfproto.GetModelStatusResponse, modelVersion int64, strategy string) *tfproto.ModelVersionStatus {
+	if len(response.GetModelVersionStatus()) == 0 {
+		return nil
+	}
+	if modelVersion == 0 {
+		return selectVersion(response.ModelVersionStatus, strategy)
+	}
+	for _, res := range response.ModelVersionStatus {
+		if modelVersion == res.Version {
+			return res
+		}
+	}
+	return nil
+}
+
+// Parse the proto msg response and map to an appropriate return value
+func (p *Prober) checkServableResponse(response *tfproto.GetModelStatusResponse, modelVersion int64) int {
+
+	// Ensure non-empty response
+	if len(response.ModelVersionStatus) == 0 {
+		p.log.Println("Empty response")
+		return ExitEmptyResponse
+	}
+
+	// No matching version found? Return early.
+	selected := selectServable(response, modelVersion, p.config.Selection)
+	if selected == nil {
+		p.log.Printf("No matching response found for version: %v\n", modelVersion)
+		return ExitVersionNotFound
+	}
+	if modelVersion == 0 && len(response.ModelVersionStatus) > 1 {
+		p.log.Printf("Selected version %v of %v\n", selected.Version, len(response.ModelVersionStatus))
+	}
+
+	// Surface the error of a failed version, such as a failed load
+	errorCode := selected.GetStatus().GetErrorCode()
+	if errorCode != tfproto.Code_OK {
+		p.log.Printf("Servable status is %v: %v\n", errorCode, selected.GetStatus().GetErrorMessage())
+	}
+
+	// Map servable states to return value
+	// https://github.com/tensorflow/serving/blob/master/tensorflow_serving/apis/get_model_status.proto
+	var retval int
+	switch selected.State {
+	case tfproto.ModelVersionStatus_AVAILABLE:
+		// servable is up and ready
+		p.log.Println("Servable state is AVAILABLE")
+		retval = ExitAvailable
+	case tfproto.ModelVersionStatus_UNKNOWN:
+		p.log.Println("Servable state is UNKNOWN")
+		retval = ExitStateUnknown
+	case tfproto.ModelVersionStatus_START:
+		p.log.Println("Servable state is START")
+		retval = ExitStateStart
+	case tfproto.ModelVersionStatus_LOADING:
+		p.log.Println("Servable state is LOADING")
+		retval = ExitStateLoading
+	case tfproto.ModelVersionStatus_UNLOADING:
+		p.log.Println("Servable state is UNLOADING")
+		retval = ExitStateUnloading
+	case tfproto.ModelVersionStatus_END:
+		// ended cleanly (unloaded), or failed with an error
+		if errorCode != tfproto.Code_OK {
+			p.log.Println("Servable state is END with an error")
+			retval = LoadFailedExitCode(errorCode)
+		} else {
+			p.log.Println("Servable state is END")
+			retval = ExitStateEnd
+		}
+	default:
+		p.log.Println("Servable state is unexpected")
+		retval = ExitUnexpectedState
+	}
+
+	// An AVAILABLE version must also meet the version constraints
+	if retval == 0 {
+		retval = p.checkVersionConstraints(response, modelVersion)
+	}
+	return retval
+}
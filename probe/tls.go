@@ -0,0 +1,125 @@
+//
+// Copyright 2020 Cody Collier <cody@telnet.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package probe
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"sync"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// NewTLSConfig builds a tls config from the ca bundle, client cert/key and
+// verify options
+func NewTLSConfig(caCert, clientCert, clientKey, serverName string, noVerify bool) (*tls.Config, error) {
+	config := &tls.Config{
+		ServerName:         serverName,
+		InsecureSkipVerify: noVerify,
+	}
+
+	if caCert != "" {
+		pem, err := ioutil.ReadFile(caCert)
+		if err != nil {
+			return nil, fmt.Errorf("reading ca cert: %v", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in ca cert: %v", caCert)
+		}
+		config.RootCAs = pool
+	}
+
+	// client cert and key are only meaningful together (mutual tls)
+	if (clientCert == "") != (clientKey == "") {
+		return nil, errors.New("client cert and client key must be provided together")
+	}
+	if clientCert != "" {
+		cert, err := tls.LoadX509KeyPair(clientCert, clientKey)
+		if err != nil {
+			return nil, fmt.Errorf("loading client cert/key: %v", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
+
+// handshakeRecorder wraps transport credentials and keeps the most recent
+// client handshake error. A blocking dial only reports the context deadline,
+// so the recorded error is used to explain tls failures after the fact.
+type handshakeRecorder struct {
+	credentials.TransportCredentials
+	last *handshakeError
+}
+
+type handshakeError struct {
+	mu  sync.Mutex
+	err error
+}
+
+func newHandshakeRecorder(creds credentials.TransportCredentials) *handshakeRecorder {
+	return &handshakeRecorder{
+		TransportCredentials: creds,
+		last:                 &handshakeError{},
+	}
+}
+
+func (r *handshakeRecorder) ClientHandshake(ctx context.Context, authority string, rawConn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	conn, info, err := r.TransportCredentials.ClientHandshake(ctx, authority, rawConn)
+	r.last.mu.Lock()
+	r.last.err = err
+	r.last.mu.Unlock()
+	return conn, info, err
+}
+
+func (r *handshakeRecorder) Clone() credentials.TransportCredentials {
+	return &handshakeRecorder{
+		TransportCredentials: r.TransportCredentials.Clone(),
+		last:                 r.last,
+	}
+}
+
+// Return the most recent handshake error, if any
+func (r *handshakeRecorder) Err() error {
+	r.last.mu.Lock()
+	defer r.last.mu.Unlock()
+	return r.last.err
+}
+
+// Map a tls handshake error to a return value. Certificate validation
+// failures are split out from other handshake failures.
+func tlsErrorCode(err error) int {
+	var unknownAuthority x509.UnknownAuthorityError
+	var hostname x509.HostnameError
+	var invalid x509.CertificateInvalidError
+	var systemRoots x509.SystemRootsError
+	switch {
+	case errors.As(err, &unknownAuthority),
+		errors.As(err, &hostname),
+		errors.As(err, &invalid),
+		errors.As(err, &systemRoots):
+		return ExitTLSCertificateInvalid
+	default:
+		return ExitTLSHandshakeFailed
+	}
+}
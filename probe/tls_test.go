@@ -0,0 +1,85 @@
+//
+// Copyright 2020 Cody Collier <cody@telnet.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package probe
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/credentials"
+)
+
+// Run a client handshake through the recorder against a local tls server
+func handshake(t *testing.T, server *httptest.Server, config *tls.Config) *handshakeRecorder {
+	creds := newHandshakeRecorder(credentials.NewTLS(config))
+	rawConn, err := net.Dial("tcp", server.Listener.Addr().String())
+	assert.Nil(t, err)
+	defer rawConn.Close()
+	conn, _, _ := creds.Clone().ClientHandshake(context.Background(), "127.0.0.1", rawConn)
+	if conn != nil {
+		conn.Close()
+	}
+	return creds
+}
+
+func TestTLSHandshakeErrors(t *testing.T) {
+	// grpc negotiates h2 via alpn
+	server := httptest.NewUnstartedServer(http.NotFoundHandler())
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+	roots := x509.NewCertPool()
+	roots.AddCert(server.Certificate())
+
+	// Unknown ca
+	creds := handshake(t, server, &tls.Config{ServerName: "127.0.0.1"})
+	assert.NotNil(t, creds.Err())
+	assert.Equal(t, 5, tlsErrorCode(creds.Err()))
+
+	// Known ca, wrong name
+	creds = handshake(t, server, &tls.Config{RootCAs: roots, ServerName: "tfs.invalid"})
+	assert.NotNil(t, creds.Err())
+	assert.Equal(t, 5, tlsErrorCode(creds.Err()))
+
+	// Known ca, matching name
+	creds = handshake(t, server, &tls.Config{RootCAs: roots, ServerName: "example.com"})
+	assert.Nil(t, creds.Err())
+}
+
+func TestTLSErrorCodeHandshake(t *testing.T) {
+	assert.Equal(t, 4, tlsErrorCode(errors.New("remote error: tls: bad certificate")))
+}
+
+func TestBuildTLSConfigErrors(t *testing.T) {
+	_, err := NewTLSConfig("/no/such/ca.pem", "", "", "", false)
+	assert.NotNil(t, err)
+
+	_, err = NewTLSConfig("", "client.pem", "", "", false)
+	assert.NotNil(t, err)
+
+	config, err := NewTLSConfig("", "", "", "tfs.example", true)
+	assert.Nil(t, err)
+	assert.Equal(t, "tfs.example", config.ServerName)
+	assert.True(t, config.InsecureSkipVerify)
+}
@@ -0,0 +1,121 @@
+//
+// Copyright 2020 Cody Collier <cody@telnet.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package probe
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"log"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/codycollier/tfs-model-status-probe/tfproto/tfproto"
+)
+
+// connector connects to TFS over the chosen protocol and builds a model
+// status fetcher on top of the connection
+type connector struct {
+	protocol       string
+	addr           string
+	tlsConfig      *tls.Config
+	connectTimeout time.Duration
+	versionFilter  string
+	dialer         func(ctx context.Context, addr string) (net.Conn, error)
+	log            *log.Logger
+	conn           *grpc.ClientConn
+}
+
+// Connect and return a model status fetcher. On failure, a non-zero return
+// value and the cause are given instead.
+func (c *connector) connect(ctx context.Context) (statusFetcher, int, error) {
+	var fetch statusFetcher
+	switch c.protocol {
+	case "grpc":
+
+		// set a timeout on the connection
+		ctxDial, cancelDial := context.WithTimeout(ctx, c.connectTimeout)
+		defer cancelDial()
+
+		// grpc connection
+		var opts []grpc.DialOption
+		var creds *handshakeRecorder
+		if c.tlsConfig != nil {
+			creds = newHandshakeRecorder(credentials.NewTLS(c.tlsConfig))
+			opts = append(opts, grpc.WithTransportCredentials(creds))
+		} else {
+			opts = append(opts, grpc.WithInsecure())
+		}
+		if c.dialer != nil {
+			opts = append(opts, grpc.WithContextDialer(c.dialer))
+		}
+		opts = append(opts, grpc.WithBlock())
+		conn, err := grpc.DialContext(ctxDial, c.addr, opts...)
+		if err != nil {
+			if creds != nil && creds.Err() != nil {
+				c.log.Printf("Error in tls handshake: %v\n", creds.Err())
+				return nil, tlsErrorCode(creds.Err()), creds.Err()
+			}
+			c.log.Printf("Error dialing grpc service: %v\n", err)
+			return nil, ExitDialFailed, err
+		}
+		c.conn = conn
+
+		// grpc client, shared by all model status calls
+		client := tfproto.NewModelServiceClient(conn)
+		fetch = func(ctx context.Context, target Model) (*tfproto.GetModelStatusResponse, error) {
+			return callModelStatus(ctx, client, target)
+		}
+
+	case "rest":
+
+		// http client, with the connect timeout applied to dial and handshake
+		client := newRESTClient(c.connectTimeout, c.tlsConfig)
+		fetch = func(ctx context.Context, target Model) (*tfproto.GetModelStatusResponse, error) {
+			statusURL := modelStatusURL(c.addr, c.tlsConfig != nil, target)
+			return callModelStatusREST(ctx, client, statusURL)
+		}
+
+	default:
+		c.log.Printf("Unknown protocol: %v\n", c.protocol)
+		return nil, ExitInvalidConfig, fmt.Errorf("unknown protocol: %v", c.protocol)
+	}
+
+	// optionally request every version and filter the response client side
+	switch c.versionFilter {
+	case "server":
+		fetch = confirmModelNotFound(fetch)
+	case "client":
+		fetch = clientSideVersionFilter(fetch)
+	default:
+		c.log.Printf("Unknown version filter: %v\n", c.versionFilter)
+		return nil, ExitInvalidConfig, fmt.Errorf("unknown version filter: %v", c.versionFilter)
+	}
+
+	return fetch, 0, nil
+}
+
+// Close the connection, if any
+func (c *connector) close() {
+	if c.conn != nil {
+		c.conn.Close()
+		c.conn = nil
+	}
+}
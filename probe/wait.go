@@ -0,0 +1,183 @@
+//
+// Copyright 2020 Cody Collier <cody@telnet.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package probe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"math/rand"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/codycollier/tfs-model-status-probe/tfproto/tfproto"
+)
+
+// Return values which waiting will not fix, such as a rejected certificate
+var waitFatalRetvals = map[int]bool{
+	ExitInvalidConfig:         true,
+	ExitTLSCertificateInvalid: true,
+	ExitVersionLabelInvalid:   true,
+	ExitVersionTimeInvalid:    true,
+}
+
+// Report whether waiting can not fix a return value. A version which failed
+// to load is not retried by the server.
+func isWaitFatal(retval int) bool {
+	return waitFatalRetvals[retval] || CategoryOf(retval) == CategoryLoadFailed
+}
+
+// stateTracker remembers the last seen state of each model version, so
+// transitions can be logged as they happen
+type stateTracker struct {
+	states map[string]tfproto.ModelVersionStatus_State
+	log    *log.Logger
+}
+
+func newStateTracker(logger *log.Logger) *stateTracker {
+	return &stateTracker{states: make(map[string]tfproto.ModelVersionStatus_State), log: logger}
+}
+
+// Log any state transitions in the results
+func (s *stateTracker) update(results []ModelResult) {
+	for _, result := range results {
+		if result.Response == nil {
+			continue
+		}
+		for _, res := range result.Response.ModelVersionStatus {
+			key := fmt.Sprintf("%v version %v", result.Model.Name, res.Version)
+			previous, seen := s.states[key]
+			if !seen {
+				s.log.Printf("Model %v: %v\n", key, res.State)
+			} else if previous != res.State {
+				s.log.Printf("Model %v: %v -> %v\n", key, previous, res.State)
+			}
+			s.states[key] = res.State
+		}
+	}
+}
+
+// Add jitter to a poll interval, spreading polls over [interval/2, interval]
+func jitter(r *rand.Rand, interval time.Duration) time.Duration {
+	half := int64(interval / 2)
+	return time.Duration(half + r.Int63n(half+1))
+}
+
+// Report whether any result failed for lack of a working connection
+func connectionFailed(results []ModelResult) bool {
+	for _, result := range results {
+		var transportErr *restTransportError
+		if errors.As(result.Err, &transportErr) || status.Code(result.Err) == codes.Unavailable {
+			return true
+		}
+	}
+	return false
+}
+
+// Report whether any result failed to reach the REST api, which is dialed
+// with each poll
+func restConnectFailed(results []ModelResult) bool {
+	for _, result := range results {
+		var transportErr *restTransportError
+		if errors.As(result.Err, &transportErr) {
+			return true
+		}
+	}
+	return false
+}
+
+// Check results without logging, for the intermediate polls
+func (p *Prober) checkModelResultsQuietly(results []ModelResult, minAvailable int) int {
+	quiet := &Prober{config: p.config, log: log.New(ioutil.Discard, "", 0)}
+	return quiet.checkModelResults(results, minAvailable)
+}
+
+// Poll model status until the models are AVAILABLE, a failure which waiting
+// will not fix is seen, or the deadline passes. The return value follows
+// checkServableResponse, with 14 when giving up at the deadline.
+func (p *Prober) waitForModels(ctx context.Context) ([]ModelResult, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.config.WaitTimeout)
+	defer cancel()
+
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	tracker := newStateTracker(p.log)
+	interval := p.config.PollInterval
+	var results []ModelResult
+	var cause error
+	retval := 0
+	for {
+
+		// (re)connect when needed, giving up on a failure unless reconnecting
+		if p.fetch == nil {
+			p.fetch, retval, cause = p.connect(ctx)
+			if p.fetch == nil {
+				results = nil
+				if !p.config.Reconnect && ctx.Err() == nil {
+					p.log.Printf("Not reconnecting (return value: %v)\n", retval)
+					return nil, retval, cause
+				}
+			}
+		}
+
+		// poll model status
+		if p.fetch != nil {
+			ctxRpc, cancelRpc := context.WithTimeout(ctx, p.config.RPCTimeout)
+			polled := p.fetchModelStatuses(ctxRpc, p.config.Models)
+			cancelRpc()
+			if ctx.Err() == nil {
+				results = polled
+				tracker.update(results)
+				retval = p.checkModelResultsQuietly(results, p.config.MinAvailable)
+			}
+			if retval != 0 && p.config.Reconnect && connectionFailed(polled) {
+				p.log.Println("Reconnecting")
+				p.disconnect()
+			}
+			if retval != 0 && !p.config.Reconnect && ctx.Err() == nil && restConnectFailed(polled) {
+				p.log.Printf("Not reconnecting (return value: %v)\n", retval)
+				return results, p.checkModelResults(results, p.config.MinAvailable), nil
+			}
+		}
+
+		// done, or nothing more to wait for
+		if ctx.Err() == nil && (retval == 0 || isWaitFatal(retval)) {
+			if results != nil {
+				return results, p.checkModelResults(results, p.config.MinAvailable), nil
+			}
+			return nil, retval, cause
+		}
+
+		// back off until the next poll, or give up at the deadline
+		select {
+		case <-ctx.Done():
+			if results != nil {
+				p.checkModelResults(results, p.config.MinAvailable)
+			}
+			p.log.Printf("Gave up waiting at deadline (last return value: %v)\n", retval)
+			return results, ExitWaitDeadline, fmt.Errorf("last return value: %v", retval)
+		case <-time.After(jitter(r, interval)):
+		}
+		interval *= 2
+		if interval > p.config.MaxPollInterval {
+			interval = p.config.MaxPollInterval
+		}
+	}
+}
@@ -0,0 +1,156 @@
+//
+// Copyright 2020 Cody Collier <cody@telnet.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package probe
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Serve a model which is LOADING for the first few polls, then AVAILABLE
+func loadingRESTServer(loadingPolls int32) *httptest.Server {
+	var polls int32
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		state := "LOADING"
+		if atomic.AddInt32(&polls, 1) > loadingPolls {
+			state = "AVAILABLE"
+		}
+		fmt.Fprintf(w, `{"model_version_status": [{"version": "123", "state": "%v"}]}`, state)
+	}))
+}
+
+func TestJitter(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		d := jitter(r, time.Second)
+		assert.True(t, d >= time.Second/2 && d <= time.Second)
+	}
+}
+
+// Build a prober which waits on a REST server, polling quickly
+func waitingProber(t *testing.T, server *httptest.Server, model Model, timeout time.Duration) *Prober {
+	p, err := New(Config{
+		Addr:            strings.TrimPrefix(server.URL, "http://"),
+		Protocol:        "rest",
+		Models:          []Model{model},
+		RPCTimeout:      time.Second,
+		Wait:            true,
+		WaitTimeout:     timeout,
+		PollInterval:    time.Millisecond * 10,
+		MaxPollInterval: time.Millisecond * 20,
+		Reconnect:       true,
+	})
+	assert.Nil(t, err)
+	return p
+}
+
+func TestWaitForModels(t *testing.T) {
+	server := loadingRESTServer(2)
+	defer server.Close()
+
+	p := waitingProber(t, server, Model{Name: "half_plus_two"}, time.Second*5)
+	results, retval, _ := p.waitForModels(context.Background())
+	assert.Equal(t, 0, retval)
+	assert.Equal(t, int64(123), results[0].Version)
+}
+
+func TestWaitForModelsDeadline(t *testing.T) {
+	server := loadingRESTServer(1000)
+	defer server.Close()
+
+	p := waitingProber(t, server, Model{Name: "half_plus_two"}, time.Millisecond*100)
+	results, retval, _ := p.waitForModels(context.Background())
+	assert.Equal(t, 14, retval)
+	assert.Equal(t, 32, results[0].ExitCode)
+}
+
+func TestWaitForModelsFatal(t *testing.T) {
+	server := restServer(map[string]string{})
+	defer server.Close()
+
+	// an unknown label is not fixed by waiting
+	p := waitingProber(t, server, Model{Name: "half_plus_two", Label: "canary"}, time.Second*5)
+	start := time.Now()
+	_, retval, _ := p.waitForModels(context.Background())
+	assert.Equal(t, 13, retval)
+	assert.True(t, time.Since(start) < time.Second)
+}
+
+func TestWaitForModelsLoadFailed(t *testing.T) {
+	server := restServer(map[string]string{
+		"/v1/models/half_plus_two": `{"model_version_status": [{"version": "123", "state": "END", "status": {"error_code": "NOT_FOUND", "error_message": "missing assets"}}]}`,
+	})
+	defer server.Close()
+
+	p := waitingProber(t, server, Model{Name: "half_plus_two"}, time.Second*5)
+	start := time.Now()
+	_, retval, _ := p.waitForModels(context.Background())
+	assert.Equal(t, 65, retval)
+	assert.True(t, time.Since(start) < time.Second)
+}
+
+func TestWaitForModelsConstraints(t *testing.T) {
+	server := restServer(map[string]string{
+		"/v1/models/half_plus_two": `{"model_version_status": [{"version": "123", "state": "AVAILABLE"}]}`,
+	})
+	defer server.Close()
+
+	// an AVAILABLE version which is too old keeps the wait going
+	p := waitingProber(t, server, Model{Name: "half_plus_two"}, time.Millisecond*100)
+	p.config.MinVersion = 200
+	results, retval, _ := p.waitForModels(context.Background())
+	assert.Equal(t, 14, retval)
+	assert.Equal(t, 21, results[0].ExitCode)
+}
+
+func TestWaitForModelsVersionTimeInvalid(t *testing.T) {
+	server := restServer(map[string]string{
+		"/v1/models/half_plus_two": `{"model_version_status": [{"version": "123", "state": "AVAILABLE"}]}`,
+	})
+	defer server.Close()
+
+	// a version which is not a timestamp is not fixed by waiting
+	p := waitingProber(t, server, Model{Name: "half_plus_two"}, time.Second*5)
+	p.config.MaxVersionAge = time.Hour
+	p.config.VersionTimeLayout = "YYYYMMDDhhmm"
+	start := time.Now()
+	_, retval, _ := p.waitForModels(context.Background())
+	assert.Equal(t, 28, retval)
+	assert.True(t, time.Since(start) < time.Second)
+}
+
+func TestWaitForModelsNoReconnect(t *testing.T) {
+	server := loadingRESTServer(0)
+	server.Close()
+
+	// a failure to connect ends the wait
+	p := waitingProber(t, server, Model{Name: "half_plus_two"}, time.Second*5)
+	p.config.Reconnect = false
+	start := time.Now()
+	_, retval, _ := p.waitForModels(context.Background())
+	assert.Equal(t, 2, retval)
+	assert.True(t, time.Since(start) < time.Second)
+}
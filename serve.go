@@ -0,0 +1,146 @@
+//
+// Copyright 2020 Cody Collier <cody@telnet.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/codycollier/tfs-model-status-probe/probe"
+)
+
+// Return a flag set for a subcommand, sharing the probe flags
+func subcommandFlags(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	flag.VisitAll(func(f *flag.Flag) {
+		fs.Var(f.Value, f.Name, f.Usage)
+	})
+	return fs
+}
+
+// healthServer serves the probe over http, for httpGet probes against a
+// sidecar. Every request runs a check over the one long-lived connection.
+type healthServer struct {
+	prober *probe.Prober
+	config probe.Config
+	mu     sync.Mutex
+	last   map[string]int
+}
+
+func newHealthServer(prober *probe.Prober) *healthServer {
+	return &healthServer{prober: prober, config: prober.Config(), last: make(map[string]int)}
+}
+
+func (s *healthServer) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/livez", s.live)
+	mux.HandleFunc("/readyz", s.ready)
+	mux.HandleFunc("/healthz", s.ready)
+	mux.HandleFunc("/ready/", s.readyModel)
+	return mux
+}
+
+// Live as long as TFS answers model status, whatever the model states
+func (s *healthServer) live(w http.ResponseWriter, r *http.Request) {
+	result := s.prober.CheckModels(r.Context(), s.config.Models)
+	ok := result.Category != probe.CategoryConnection && result.Category != probe.CategoryRPC
+	s.respond(w, r, result, ok)
+}
+
+// Ready when the full check passes, as with the one-shot probe
+func (s *healthServer) ready(w http.ResponseWriter, r *http.Request) {
+	result := s.prober.Check(r.Context())
+	s.respond(w, r, result, result.ExitCode == probe.ExitAvailable)
+}
+
+// Ready when a single model, as name, name:version or name@label, is
+// AVAILABLE. The model does not need to be one of the configured models.
+func (s *healthServer) readyModel(w http.ResponseWriter, r *http.Request) {
+	model, err := probe.ParseModel(strings.TrimPrefix(r.URL.Path, "/ready/"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	result := s.prober.CheckModels(r.Context(), []probe.Model{model})
+	s.respond(w, r, result, result.ExitCode == probe.ExitAvailable)
+}
+
+// Write the json report with 200 when ok, or 503, and log changes of the
+// exit code per path
+func (s *healthServer) respond(w http.ResponseWriter, r *http.Request, result *probe.Result, ok bool) {
+	s.mu.Lock()
+	previous, seen := s.last[r.URL.Path]
+	s.last[r.URL.Path] = result.ExitCode
+	s.mu.Unlock()
+	if !seen || previous != result.ExitCode {
+		log.Printf("%v: %v (%v)\n", r.URL.Path, result.ExitCode, probe.ExitCodeText(result.ExitCode))
+	}
+
+	code := http.StatusOK
+	if !ok {
+		code = http.StatusServiceUnavailable
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(newJSONReport(s.config, result))
+}
+
+// Run the serve subcommand until interrupted, returning the exit code
+func runServe(args []string) int {
+	fs := subcommandFlags("serve")
+	listen := fs.String("listen", ":8080", "The host:port to serve /healthz, /readyz, /livez and /ready/{model} on")
+	fs.Parse(args)
+
+	config, err := configFromFlags()
+	if err == nil && config.Wait {
+		err = errors.New("the -wait option is not supported by serve")
+	}
+	var p *probe.Prober
+	if err == nil {
+		config.Logger = nil
+		p, err = probe.New(config)
+	}
+	if err != nil {
+		log.Printf("Error: %v\n", err)
+		return probe.ExitInvalidConfig
+	}
+	defer p.Close()
+
+	server := &http.Server{Addr: *listen, Handler: newHealthServer(p).handler()}
+	go func() {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+		<-signals
+		server.Shutdown(context.Background())
+	}()
+
+	log.Printf("Serving health checks for %v on %v\n", config.Addr, *listen)
+	if err := server.ListenAndServe(); err != http.ErrServerClosed {
+		log.Printf("Error serving: %v\n", err)
+		return probe.ExitInvalidConfig
+	}
+	return probe.ExitAvailable
+}
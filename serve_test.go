@@ -0,0 +1,103 @@
+//
+// Copyright 2020 Cody Collier <cody@telnet.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/codycollier/tfs-model-status-probe/probe"
+)
+
+// Serve model states over the TFS REST api, keyed by model name
+func fakeRESTServer(states map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/v1/models/"), "/", 2)[0]
+		state, ok := states[name]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintf(w, `{"error": "Could not find any versions of model %v"}`, name)
+			return
+		}
+		fmt.Fprintf(w, `{"model_version_status": [{"version": "1", "state": "%v"}]}`, state)
+	}))
+}
+
+func TestHealthServer(t *testing.T) {
+	tfs := fakeRESTServer(map[string]string{"half_plus_two": "AVAILABLE", "resnet": "LOADING"})
+	defer tfs.Close()
+
+	config := probe.Config{
+		Addr:     strings.TrimPrefix(tfs.URL, "http://"),
+		Protocol: "rest",
+		Models:   []probe.Model{{Name: "half_plus_two"}, {Name: "resnet"}},
+	}
+	p, err := probe.New(config)
+	assert.Nil(t, err)
+	defer p.Close()
+	server := httptest.NewServer(newHealthServer(p).handler())
+	defer server.Close()
+
+	expected := map[string]int{
+		"/livez":                 http.StatusOK,
+		"/readyz":                http.StatusServiceUnavailable,
+		"/healthz":               http.StatusServiceUnavailable,
+		"/ready/half_plus_two":   http.StatusOK,
+		"/ready/half_plus_two:1": http.StatusOK,
+		"/ready/resnet":          http.StatusServiceUnavailable,
+		"/ready/missing":         http.StatusServiceUnavailable,
+		"/ready/":                http.StatusBadRequest,
+	}
+	for path, code := range expected {
+		response, err := http.Get(server.URL + path)
+		assert.Nil(t, err)
+		response.Body.Close()
+		assert.Equal(t, code, response.StatusCode, path)
+	}
+
+	// The body is the json report
+	response, err := http.Get(server.URL + "/ready/resnet")
+	assert.Nil(t, err)
+	defer response.Body.Close()
+	var report jsonReport
+	assert.Nil(t, json.NewDecoder(response.Body).Decode(&report))
+	assert.Equal(t, 32, report.ExitCode)
+	assert.Equal(t, "LOADING", report.Models[0].State)
+}
+
+func TestHealthServerLive(t *testing.T) {
+	tfs := fakeRESTServer(map[string]string{})
+	addr := strings.TrimPrefix(tfs.URL, "http://")
+	tfs.Close()
+
+	config := probe.Config{Addr: addr, Protocol: "rest"}
+	p, err := probe.New(config)
+	assert.Nil(t, err)
+	server := httptest.NewServer(newHealthServer(p).handler())
+	defer server.Close()
+
+	response, err := http.Get(server.URL + "/livez")
+	assert.Nil(t, err)
+	response.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, response.StatusCode)
+}
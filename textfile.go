@@ -0,0 +1,76 @@
+//
+// Copyright 2020 Cody Collier <cody@telnet.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package main
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/codycollier/tfs-model-status-probe/probe"
+)
+
+var (
+	probeExitCodeDesc = prometheus.NewDesc(
+		"tfs_probe_exit_code",
+		"The exit code of the probe.",
+		nil, nil,
+	)
+	probeDurationDesc = prometheus.NewDesc(
+		"tfs_probe_duration_seconds",
+		"Duration of the probe, by phase: dial, rpc or total.",
+		[]string{"phase"}, nil,
+	)
+	probeTimestampDesc = prometheus.NewDesc(
+		"tfs_probe_last_run_timestamp_seconds",
+		"Unix time at which the probe finished.",
+		nil, nil,
+	)
+)
+
+// resultCollector exposes the result of a single probe run
+type resultCollector struct {
+	result *probe.Result
+	time   time.Time
+}
+
+func (c *resultCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- versionStateDesc
+	ch <- modelAvailableDesc
+	ch <- modelExitCodeDesc
+	ch <- probeExitCodeDesc
+	ch <- probeDurationDesc
+	ch <- probeTimestampDesc
+}
+
+func (c *resultCollector) Collect(ch chan<- prometheus.Metric) {
+	collectModelResults(ch, c.result.Models)
+	ch <- prometheus.MustNewConstMetric(probeExitCodeDesc, prometheus.GaugeValue, float64(c.result.ExitCode))
+	ch <- prometheus.MustNewConstMetric(probeDurationDesc, prometheus.GaugeValue, c.result.DialLatency.Seconds(), "dial")
+	ch <- prometheus.MustNewConstMetric(probeDurationDesc, prometheus.GaugeValue, c.result.RPCLatency.Seconds(), "rpc")
+	ch <- prometheus.MustNewConstMetric(probeDurationDesc, prometheus.GaugeValue, c.result.Latency.Seconds(), "total")
+	ch <- prometheus.MustNewConstMetric(probeTimestampDesc, prometheus.GaugeValue, float64(c.time.UnixNano())/1e9)
+}
+
+// Write the probe result in the prometheus text format for the node_exporter
+// textfile collector. The file is written to a temp file and renamed into
+// place, so the collector never reads a partial file.
+func writeTextfile(path string, result *probe.Result) error {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(&resultCollector{result: result, time: time.Now()})
+	return prometheus.WriteToTextfile(path, registry)
+}
@@ -0,0 +1,75 @@
+//
+// Copyright 2020 Cody Collier <cody@telnet.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/codycollier/tfs-model-status-probe/probe"
+	"github.com/codycollier/tfs-model-status-probe/tfproto/tfproto"
+)
+
+func TestWriteTextfile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "tfs_model_status.prom")
+	result := &probe.Result{
+		ExitCode: 32,
+		Models: []probe.ModelResult{{
+			Model: probe.Model{Name: "half_plus_two"},
+			Response: &tfproto.GetModelStatusResponse{
+				ModelVersionStatus: []*tfproto.ModelVersionStatus{
+					{Version: 1, State: tfproto.ModelVersionStatus_LOADING},
+				},
+			},
+			ExitCode: 32,
+		}},
+		DialLatency: 2 * time.Millisecond,
+		RPCLatency:  5 * time.Millisecond,
+		Latency:     8 * time.Millisecond,
+	}
+	assert.Nil(t, writeTextfile(path, result))
+
+	// the temp file is renamed into place
+	files, err := ioutil.ReadDir(dir)
+	assert.Nil(t, err)
+	assert.Len(t, files, 1)
+
+	data, err := ioutil.ReadFile(path)
+	assert.Nil(t, err)
+	text := string(data)
+	assert.Contains(t, text, `tfs_model_version_state{model="half_plus_two",state="LOADING",version="1"} 1`)
+	assert.Contains(t, text, `tfs_model_version_state{model="half_plus_two",state="AVAILABLE",version="1"} 0`)
+	assert.Contains(t, text, `tfs_model_available{model="half_plus_two"} 0`)
+	assert.Contains(t, text, `tfs_model_exit_code{model="half_plus_two"} 32`)
+	assert.Contains(t, text, "tfs_probe_exit_code 32")
+	assert.Contains(t, text, `tfs_probe_duration_seconds{phase="dial"} 0.002`)
+	assert.Contains(t, text, `tfs_probe_duration_seconds{phase="rpc"} 0.005`)
+	assert.Contains(t, text, `tfs_probe_duration_seconds{phase="total"} 0.008`)
+	assert.Contains(t, text, "tfs_probe_last_run_timestamp_seconds")
+
+	// a failed run without model results still reports the exit code
+	assert.Nil(t, writeTextfile(path, &probe.Result{ExitCode: 2}))
+	data, err = ioutil.ReadFile(path)
+	assert.Nil(t, err)
+	assert.Contains(t, string(data), "tfs_probe_exit_code 2")
+	assert.NotContains(t, string(data), "tfs_model_version_state")
+}
@@ -0,0 +1,498 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.23.0
+// 	protoc        v3.13.0
+// source: meta_graph.proto
+
+package tfproto
+
+import (
+	proto "github.com/golang/protobuf/proto"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+// Information about a Tensor necessary for feeding or retrieval.
+type TensorInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Encoding:
+	//	*TensorInfo_Name
+	//	*TensorInfo_CooSparse_
+	Encoding isTensorInfo_Encoding `protobuf_oneof:"encoding"`
+	Dtype    DataType              `protobuf:"varint,2,opt,name=dtype,proto3,enum=tensorflow.DataType" json:"dtype,omitempty"`
+	// The static shape should be recorded here, to the extent that it can
+	// be known in advance.  In the case of a SparseTensor, this field describes
+	// the logical shape of the represented tensor (aka dense_shape).
+	TensorShape *TensorShapeProto `protobuf:"bytes,3,opt,name=tensor_shape,json=tensorShape,proto3" json:"tensor_shape,omitempty"`
+}
+
+func (x *TensorInfo) Reset() {
+	*x = TensorInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_meta_graph_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TensorInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TensorInfo) ProtoMessage() {}
+
+func (x *TensorInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_meta_graph_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TensorInfo.ProtoReflect.Descriptor instead.
+func (*TensorInfo) Descriptor() ([]byte, []int) {
+	return file_meta_graph_proto_rawDescGZIP(), []int{0}
+}
+
+func (m *TensorInfo) GetEncoding() isTensorInfo_Encoding {
+	if m != nil {
+		return m.Encoding
+	}
+	return nil
+}
+
+func (x *TensorInfo) GetName() string {
+	if x, ok := x.GetEncoding().(*TensorInfo_Name); ok {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TensorInfo) GetCooSparse() *TensorInfo_CooSparse {
+	if x, ok := x.GetEncoding().(*TensorInfo_CooSparse_); ok {
+		return x.CooSparse
+	}
+	return nil
+}
+
+func (x *TensorInfo) GetDtype() DataType {
+	if x != nil {
+		return x.Dtype
+	}
+	return DataType_DT_INVALID
+}
+
+func (x *TensorInfo) GetTensorShape() *TensorShapeProto {
+	if x != nil {
+		return x.TensorShape
+	}
+	return nil
+}
+
+type isTensorInfo_Encoding interface {
+	isTensorInfo_Encoding()
+}
+
+type TensorInfo_Name struct {
+	// For dense `Tensor`s, the name of the tensor in the graph.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3,oneof"`
+}
+
+type TensorInfo_CooSparse_ struct {
+	// There are many possible encodings of sparse matrices
+	// (https://en.wikipedia.org/wiki/Sparse_matrix).  Currently, TensorFlow
+	// uses only the COO encoding.  This is supported and documented in the
+	// SparseTensor Python class.
+	CooSparse *TensorInfo_CooSparse `protobuf:"bytes,4,opt,name=coo_sparse,json=cooSparse,proto3,oneof"`
+}
+
+func (*TensorInfo_Name) isTensorInfo_Encoding() {}
+
+func (*TensorInfo_CooSparse_) isTensorInfo_Encoding() {}
+
+// SignatureDef defines the signature of a computation supported by a TensorFlow
+// graph.
+//
+// For example, a model with two loss computations, sharing a single input,
+// might have the following signature_def map.
+//
+// Note that across the two SignatureDefs "loss_A" and "loss_B", the input key,
+// output key, and method_name are identical, and will be used by system(s) that
+// implement or rely upon this particular loss method. The output tensor names
+// differ, demonstrating how different outputs can exist for the same method.
+//
+//	signature_def {
+//	  key: "loss_A"
+//	  value {
+//	    inputs {
+//	      key: "input"
+//	      value {
+//	        name: "input:0"
+//	        dtype: DT_STRING
+//	        tensor_shape: ...
+//	      }
+//	    }
+//	    outputs {
+//	      key: "loss_output"
+//	      value {
+//	        name: "loss_output_A:0"
+//	        dtype: DT_FLOAT
+//	        tensor_shape: ...
+//	      }
+//	    }
+//	  }
+//	  ...
+//	  method_name: "some/package/compute_loss"
+//	}
+//
+//	signature_def {
+//	  key: "loss_B"
+//	  value {
+//	    inputs {
+//	      key: "input"
+//	      value {
+//	        name: "input:0"
+//	        dtype: DT_STRING
+//	        tensor_shape: ...
+//	      }
+//	    }
+//	    outputs {
+//	      key: "loss_output"
+//	      value {
+//	        name: "loss_output_B:0"
+//	        dtype: DT_FLOAT
+//	        tensor_shape: ...
+//	      }
+//	    }
+//	  }
+//	  ...
+//	  method_name: "some/package/compute_loss"
+//	}
+type SignatureDef struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Named input parameters.
+	Inputs map[string]*TensorInfo `protobuf:"bytes,1,rep,name=inputs,proto3" json:"inputs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Named output parameters.
+	Outputs map[string]*TensorInfo `protobuf:"bytes,2,rep,name=outputs,proto3" json:"outputs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Extensible method_name information enabling third-party users to mark a
+	// SignatureDef as supporting a particular method. This enables producers and
+	// consumers of SignatureDefs, e.g. a model definition library and a serving
+	// library to have a clear hand-off regarding the semantics of a computation.
+	//
+	// Note that multiple SignatureDefs in a single MetaGraphDef may have the same
+	// method_name. This is commonly used to support multi-headed computation,
+	// where a single graph computation may return multiple results.
+	MethodName string `protobuf:"bytes,3,opt,name=method_name,json=methodName,proto3" json:"method_name,omitempty"`
+}
+
+func (x *SignatureDef) Reset() {
+	*x = SignatureDef{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_meta_graph_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignatureDef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignatureDef) ProtoMessage() {}
+
+func (x *SignatureDef) ProtoReflect() protoreflect.Message {
+	mi := &file_meta_graph_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignatureDef.ProtoReflect.Descriptor instead.
+func (*SignatureDef) Descriptor() ([]byte, []int) {
+	return file_meta_graph_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SignatureDef) GetInputs() map[string]*TensorInfo {
+	if x != nil {
+		return x.Inputs
+	}
+	return nil
+}
+
+func (x *SignatureDef) GetOutputs() map[string]*TensorInfo {
+	if x != nil {
+		return x.Outputs
+	}
+	return nil
+}
+
+func (x *SignatureDef) GetMethodName() string {
+	if x != nil {
+		return x.MethodName
+	}
+	return ""
+}
+
+// For sparse tensors, The COO encoding stores a triple of values, indices,
+// and shape.
+type TensorInfo_CooSparse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The shape of the values Tensor is [?].  Its dtype must be the dtype of
+	// the SparseTensor as a whole, given in the enclosing TensorInfo.
+	ValuesTensorName string `protobuf:"bytes,1,opt,name=values_tensor_name,json=valuesTensorName,proto3" json:"values_tensor_name,omitempty"`
+	// The indices Tensor must have dtype int64 and shape [?, ?].
+	IndicesTensorName string `protobuf:"bytes,2,opt,name=indices_tensor_name,json=indicesTensorName,proto3" json:"indices_tensor_name,omitempty"`
+	// The dynamic logical shape represented by the SparseTensor is recorded in
+	// the Tensor referenced here.  It must have dtype int64 and shape [?].
+	DenseShapeTensorName string `protobuf:"bytes,3,opt,name=dense_shape_tensor_name,json=denseShapeTensorName,proto3" json:"dense_shape_tensor_name,omitempty"`
+}
+
+func (x *TensorInfo_CooSparse) Reset() {
+	*x = TensorInfo_CooSparse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_meta_graph_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TensorInfo_CooSparse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TensorInfo_CooSparse) ProtoMessage() {}
+
+func (x *TensorInfo_CooSparse) ProtoReflect() protoreflect.Message {
+	mi := &file_meta_graph_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TensorInfo_CooSparse.ProtoReflect.Descriptor instead.
+func (*TensorInfo_CooSparse) Descriptor() ([]byte, []int) {
+	return file_meta_graph_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *TensorInfo_CooSparse) GetValuesTensorName() string {
+	if x != nil {
+		return x.ValuesTensorName
+	}
+	return ""
+}
+
+func (x *TensorInfo_CooSparse) GetIndicesTensorName() string {
+	if x != nil {
+		return x.IndicesTensorName
+	}
+	return ""
+}
+
+func (x *TensorInfo_CooSparse) GetDenseShapeTensorName() string {
+	if x != nil {
+		return x.DenseShapeTensorName
+	}
+	return ""
+}
+
+var File_meta_graph_proto protoreflect.FileDescriptor
+
+var file_meta_graph_proto_rawDesc = []byte{
+	0x0a, 0x10, 0x6d, 0x65, 0x74, 0x61, 0x5f, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x0a, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x66, 0x6c, 0x6f, 0x77, 0x1a, 0x12,
+	0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x5f, 0x73, 0x68, 0x61, 0x70, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x1a, 0x0b, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
+	0x87, 0x03, 0x0a, 0x0a, 0x54, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x14,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x41, 0x0a, 0x0a, 0x63, 0x6f, 0x6f, 0x5f, 0x73, 0x70, 0x61, 0x72,
+	0x73, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f,
+	0x72, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x54, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x49, 0x6e, 0x66, 0x6f,
+	0x2e, 0x43, 0x6f, 0x6f, 0x53, 0x70, 0x61, 0x72, 0x73, 0x65, 0x48, 0x00, 0x52, 0x09, 0x63, 0x6f,
+	0x6f, 0x53, 0x70, 0x61, 0x72, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x05, 0x64, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x66,
+	0x6c, 0x6f, 0x77, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x54, 0x79, 0x70, 0x65, 0x52, 0x05, 0x64, 0x74,
+	0x79, 0x70, 0x65, 0x12, 0x3f, 0x0a, 0x0c, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x5f, 0x73, 0x68,
+	0x61, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x74, 0x65, 0x6e, 0x73,
+	0x6f, 0x72, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x54, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x53, 0x68, 0x61,
+	0x70, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x52, 0x0b, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x53,
+	0x68, 0x61, 0x70, 0x65, 0x1a, 0xa0, 0x01, 0x0a, 0x09, 0x43, 0x6f, 0x6f, 0x53, 0x70, 0x61, 0x72,
+	0x73, 0x65, 0x12, 0x2c, 0x0a, 0x12, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x5f, 0x74, 0x65, 0x6e,
+	0x73, 0x6f, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x54, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x2e, 0x0a, 0x13, 0x69, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x5f, 0x74, 0x65, 0x6e, 0x73,
+	0x6f, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x69,
+	0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x54, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x35, 0x0a, 0x17, 0x64, 0x65, 0x6e, 0x73, 0x65, 0x5f, 0x73, 0x68, 0x61, 0x70, 0x65, 0x5f,
+	0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x14, 0x64, 0x65, 0x6e, 0x73, 0x65, 0x53, 0x68, 0x61, 0x70, 0x65, 0x54, 0x65, 0x6e,
+	0x73, 0x6f, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x42, 0x0a, 0x0a, 0x08, 0x65, 0x6e, 0x63, 0x6f, 0x64,
+	0x69, 0x6e, 0x67, 0x4a, 0x04, 0x08, 0x05, 0x10, 0x06, 0x22, 0xd5, 0x02, 0x0a, 0x0c, 0x53, 0x69,
+	0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x44, 0x65, 0x66, 0x12, 0x3c, 0x0a, 0x06, 0x69, 0x6e,
+	0x70, 0x75, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x74, 0x65, 0x6e,
+	0x73, 0x6f, 0x72, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x44, 0x65, 0x66, 0x2e, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x06, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x12, 0x3f, 0x0a, 0x07, 0x6f, 0x75, 0x74, 0x70,
+	0x75, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x74, 0x65, 0x6e, 0x73,
+	0x6f, 0x72, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x44, 0x65, 0x66, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x65, 0x74,
+	0x68, 0x6f, 0x64, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x1a, 0x51, 0x0a, 0x0b, 0x49, 0x6e,
+	0x70, 0x75, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2c, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x74, 0x65, 0x6e,
+	0x73, 0x6f, 0x72, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x54, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x49, 0x6e,
+	0x66, 0x6f, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x52, 0x0a,
+	0x0c, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x2c, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16,
+	0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x54, 0x65, 0x6e, 0x73,
+	0x6f, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x42, 0x3b, 0x0a, 0x18, 0x6f, 0x72, 0x67, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x66,
+	0x6c, 0x6f, 0x77, 0x2e, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x42, 0x0f, 0x4d,
+	0x65, 0x74, 0x61, 0x47, 0x72, 0x61, 0x70, 0x68, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x50, 0x01,
+	0x5a, 0x09, 0x2e, 0x3b, 0x74, 0x66, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0xf8, 0x01, 0x01, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_meta_graph_proto_rawDescOnce sync.Once
+	file_meta_graph_proto_rawDescData = file_meta_graph_proto_rawDesc
+)
+
+func file_meta_graph_proto_rawDescGZIP() []byte {
+	file_meta_graph_proto_rawDescOnce.Do(func() {
+		file_meta_graph_proto_rawDescData = protoimpl.X.CompressGZIP(file_meta_graph_proto_rawDescData)
+	})
+	return file_meta_graph_proto_rawDescData
+}
+
+var file_meta_graph_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_meta_graph_proto_goTypes = []interface{}{
+	(*TensorInfo)(nil),           // 0: tensorflow.TensorInfo
+	(*SignatureDef)(nil),         // 1: tensorflow.SignatureDef
+	(*TensorInfo_CooSparse)(nil), // 2: tensorflow.TensorInfo.CooSparse
+	nil,                          // 3: tensorflow.SignatureDef.InputsEntry
+	nil,                          // 4: tensorflow.SignatureDef.OutputsEntry
+	(DataType)(0),                // 5: tensorflow.DataType
+	(*TensorShapeProto)(nil),     // 6: tensorflow.TensorShapeProto
+}
+var file_meta_graph_proto_depIdxs = []int32{
+	2, // 0: tensorflow.TensorInfo.coo_sparse:type_name -> tensorflow.TensorInfo.CooSparse
+	5, // 1: tensorflow.TensorInfo.dtype:type_name -> tensorflow.DataType
+	6, // 2: tensorflow.TensorInfo.tensor_shape:type_name -> tensorflow.TensorShapeProto
+	3, // 3: tensorflow.SignatureDef.inputs:type_name -> tensorflow.SignatureDef.InputsEntry
+	4, // 4: tensorflow.SignatureDef.outputs:type_name -> tensorflow.SignatureDef.OutputsEntry
+	0, // 5: tensorflow.SignatureDef.InputsEntry.value:type_name -> tensorflow.TensorInfo
+	0, // 6: tensorflow.SignatureDef.OutputsEntry.value:type_name -> tensorflow.TensorInfo
+	7, // [7:7] is the sub-list for method output_type
+	7, // [7:7] is the sub-list for method input_type
+	7, // [7:7] is the sub-list for extension type_name
+	7, // [7:7] is the sub-list for extension extendee
+	0, // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_meta_graph_proto_init() }
+func file_meta_graph_proto_init() {
+	if File_meta_graph_proto != nil {
+		return
+	}
+	file_tensor_shape_proto_init()
+	file_types_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_meta_graph_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TensorInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_meta_graph_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignatureDef); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_meta_graph_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TensorInfo_CooSparse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_meta_graph_proto_msgTypes[0].OneofWrappers = []interface{}{
+		(*TensorInfo_Name)(nil),
+		(*TensorInfo_CooSparse_)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_meta_graph_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_meta_graph_proto_goTypes,
+		DependencyIndexes: file_meta_graph_proto_depIdxs,
+		MessageInfos:      file_meta_graph_proto_msgTypes,
+	}.Build()
+	File_meta_graph_proto = out.File
+	file_meta_graph_proto_rawDesc = nil
+	file_meta_graph_proto_goTypes = nil
+	file_meta_graph_proto_depIdxs = nil
+}
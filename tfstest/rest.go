@@ -0,0 +1,105 @@
+//
+// Copyright 2020 Cody Collier <cody@telnet.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package tfstest
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/codycollier/tfs-model-status-probe/tfproto/tfproto"
+)
+
+// The http status TFS answers with for each grpc code
+var grpcHTTPStatus = map[codes.Code]int{
+	codes.InvalidArgument:   http.StatusBadRequest,
+	codes.Unauthenticated:   http.StatusUnauthorized,
+	codes.PermissionDenied:  http.StatusForbidden,
+	codes.NotFound:          http.StatusNotFound,
+	codes.AlreadyExists:     http.StatusConflict,
+	codes.ResourceExhausted: http.StatusTooManyRequests,
+	codes.Unimplemented:     http.StatusNotImplemented,
+	codes.Unavailable:       http.StatusServiceUnavailable,
+	codes.DeadlineExceeded:  http.StatusGatewayTimeout,
+	codes.Canceled:          http.StatusGatewayTimeout,
+}
+
+// RESTHandler serves the same models over the TFS REST api, at
+// GET /v1/models/{name}[/versions/{v}|/labels/{l}]
+func (s *Server) RESTHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		spec, ok := parseModelPath(r.URL.Path)
+		if !ok || r.Method != http.MethodGet {
+			writeRESTError(w, status.Errorf(codes.NotFound, "Malformed request: %v %v", r.Method, r.URL.Path))
+			return
+		}
+		response, err := s.modelStatus(r.Context(), spec)
+		if err != nil {
+			writeRESTError(w, err)
+			return
+		}
+		body, err := protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}.Marshal(response)
+		if err != nil {
+			writeRESTError(w, status.Error(codes.Internal, err.Error()))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+	})
+}
+
+// Parse a model status path into a model spec
+func parseModelPath(path string) (*tfproto.ModelSpec, bool) {
+	parts := strings.Split(strings.TrimPrefix(path, "/v1/models/"), "/")
+	if !strings.HasPrefix(path, "/v1/models/") || parts[0] == "" {
+		return nil, false
+	}
+	spec := &tfproto.ModelSpec{Name: parts[0]}
+	switch {
+	case len(parts) == 1:
+	case len(parts) == 3 && parts[1] == "versions":
+		version, err := strconv.ParseInt(parts[2], 10, 64)
+		if err != nil {
+			return nil, false
+		}
+		spec.VersionChoice = &tfproto.ModelSpec_Version{Version: wrapperspb.Int64(version)}
+	case len(parts) == 3 && parts[1] == "labels":
+		spec.VersionChoice = &tfproto.ModelSpec_VersionLabel{VersionLabel: parts[2]}
+	default:
+		return nil, false
+	}
+	return spec, true
+}
+
+// Write an error as TFS does, {"error": "..."} with a matching http status
+func writeRESTError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+	code, ok := grpcHTTPStatus[st.Code()]
+	if !ok {
+		code = http.StatusInternalServerError
+	}
+	body, _ := json.Marshal(map[string]string{"error": st.Message()})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(body)
+}
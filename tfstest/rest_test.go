@@ -0,0 +1,74 @@
+//
+// Copyright 2020 Cody Collier <cody@telnet.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package tfstest
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/codycollier/tfs-model-status-probe/probe"
+	"github.com/codycollier/tfs-model-status-probe/tfproto/tfproto"
+)
+
+func TestRESTHandler(t *testing.T) {
+	server := New(
+		Model{Name: "half_plus_two", Versions: []Version{{Version: 1, Timeline: Available()}}, Labels: map[string]int64{"stable": 1}},
+		Model{Name: "resnet", Versions: []Version{{Version: 1, Timeline: []Step{{State: tfproto.ModelVersionStatus_LOADING}}}}},
+	)
+	rest := httptest.NewServer(server.RESTHandler())
+	defer rest.Close()
+
+	response, err := http.Get(rest.URL + "/v1/models/half_plus_two")
+	assert.Nil(t, err)
+	body, _ := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.JSONEq(t, `{"model_version_status": [{"version": "1", "state": "AVAILABLE", "status": {"error_code": "OK", "error_message": ""}}]}`, string(body))
+
+	response, err = http.Get(rest.URL + "/v1/models/half_plus_two/bogus")
+	assert.Nil(t, err)
+	response.Body.Close()
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+
+	// the probe sees the same exit codes as over grpc
+	expected := map[probe.Model]int{
+		{Name: "half_plus_two"}:                  0,
+		{Name: "half_plus_two", Version: 1}:      0,
+		{Name: "half_plus_two", Version: 2}:      12,
+		{Name: "half_plus_two", Label: "stable"}: 0,
+		{Name: "half_plus_two", Label: "canary"}: 13,
+		{Name: "resnet"}:                         32,
+		{Name: "missing"}:                        10,
+		{Name: "missing", Version: 5}:            10,
+	}
+	for model, code := range expected {
+		p, err := probe.New(probe.Config{
+			Addr:     strings.TrimPrefix(rest.URL, "http://"),
+			Protocol: "rest",
+			Models:   []probe.Model{model},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, code, p.Check(context.Background()).ExitCode, model.String())
+		p.Close()
+	}
+}
@@ -0,0 +1,259 @@
+//
+// Copyright 2020 Cody Collier <cody@telnet.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package tfstest provides a scripted fake of the TensorFlow Serving
+// ModelService, for testing the probe end to end without a real server.
+//
+// Each model version follows a timeline of states relative to the server
+// start, and a model can return an injected grpc error, respond slowly or
+// hang until the call is cancelled. The server runs on a local port, or in
+// memory over bufconn:
+//
+//	server := tfstest.NewBufconnServer(tfstest.Model{
+//		Name: "half_plus_two",
+//		Versions: []tfstest.Version{{Version: 1, Timeline: []tfstest.Step{
+//			{State: tfproto.ModelVersionStatus_LOADING},
+//			{After: time.Second, State: tfproto.ModelVersionStatus_AVAILABLE},
+//		}}},
+//	})
+//	defer server.Close()
+//	p, err := probe.New(probe.Config{Addr: server.Addr, Dialer: server.Dialer()})
+package tfstest
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/codycollier/tfs-model-status-probe/tfproto/tfproto"
+)
+
+// Model is a scripted model. Err, when set, is returned instead of the
+// status, after any Latency. A model which hangs only returns once the
+// call is cancelled.
+type Model struct {
+	Name     string
+	Versions []Version
+	Labels   map[string]int64 // version labels, resolved by the server
+	Err      error
+	Latency  time.Duration
+	Hang     bool
+}
+
+// Version is a model version following a timeline of states. The version
+// is not reported until its first step.
+type Version struct {
+	Version  int64
+	Timeline []Step
+}
+
+// Step is the state of a version from After, relative to the server start.
+// A non-OK ErrorCode is reported as the status of the version.
+type Step struct {
+	After        time.Duration
+	State        tfproto.ModelVersionStatus_State
+	ErrorCode    tfproto.Code
+	ErrorMessage string
+}
+
+// Available is a timeline with the version AVAILABLE from the start
+func Available() []Step {
+	return []Step{{State: tfproto.ModelVersionStatus_AVAILABLE}}
+}
+
+// Server is a fake ModelService. Addr is the host:port of a server on a
+// local port, or "bufconn" for an in-memory server.
+type Server struct {
+	tfproto.UnimplementedModelServiceServer
+	Addr string
+
+	mu     sync.Mutex
+	models map[string]Model
+	start  time.Time
+	calls  int
+
+	grpcServer *grpc.Server
+	bufconn    *bufconn.Listener
+}
+
+// New returns an unstarted server, to register on a grpc server of your own
+func New(models ...Model) *Server {
+	s := &Server{models: map[string]Model{}, start: time.Now()}
+	for _, model := range models {
+		s.models[model.Name] = model
+	}
+	return s
+}
+
+// NewServer starts a server on a local port. It panics if it cannot listen,
+// as httptest.NewServer does.
+func NewServer(models ...Model) *Server {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic(fmt.Sprintf("tfstest: failed to listen on a port: %v", err))
+	}
+	s := New(models...)
+	s.Addr = listener.Addr().String()
+	s.serve(listener)
+	return s
+}
+
+// NewBufconnServer starts an in-memory server. Connect with Dialer.
+func NewBufconnServer(models ...Model) *Server {
+	s := New(models...)
+	s.Addr = "bufconn"
+	s.bufconn = bufconn.Listen(1024 * 1024)
+	s.serve(s.bufconn)
+	return s
+}
+
+func (s *Server) serve(listener net.Listener) {
+	s.grpcServer = grpc.NewServer()
+	s.Register(s.grpcServer)
+	go s.grpcServer.Serve(listener)
+}
+
+// Register the fake model service on a grpc server
+func (s *Server) Register(server *grpc.Server) {
+	tfproto.RegisterModelServiceServer(server, s)
+}
+
+// Dialer returns a dialer connecting to the server, whatever the address
+func (s *Server) Dialer() func(ctx context.Context, addr string) (net.Conn, error) {
+	return func(ctx context.Context, addr string) (net.Conn, error) {
+		if s.bufconn != nil {
+			return s.bufconn.Dial()
+		}
+		var d net.Dialer
+		return d.DialContext(ctx, "tcp", s.Addr)
+	}
+}
+
+// Close stops the server, closing open connections
+func (s *Server) Close() {
+	if s.grpcServer != nil {
+		s.grpcServer.Stop()
+	}
+}
+
+// SetModel adds or replaces a model while the server is running
+func (s *Server) SetModel(model Model) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.models[model.Name] = model
+}
+
+// RemoveModel removes a model, which is then not found
+func (s *Server) RemoveModel(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.models, name)
+}
+
+// Calls returns the number of model status calls served
+func (s *Server) Calls() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls
+}
+
+// GetModelStatus answers as TFS would, from the model script
+func (s *Server) GetModelStatus(ctx context.Context, in *tfproto.GetModelStatusRequest) (*tfproto.GetModelStatusResponse, error) {
+	return s.modelStatus(ctx, in.GetModelSpec())
+}
+
+// Answer a model status call over either api
+func (s *Server) modelStatus(ctx context.Context, spec *tfproto.ModelSpec) (*tfproto.GetModelStatusResponse, error) {
+	s.mu.Lock()
+	s.calls++
+	model, ok := s.models[spec.GetName()]
+	elapsed := time.Since(s.start)
+	s.mu.Unlock()
+
+	if ok && model.Hang {
+		<-ctx.Done()
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+	if ok && model.Latency > 0 {
+		select {
+		case <-time.After(model.Latency):
+		case <-ctx.Done():
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+	}
+	if !ok {
+		if version := spec.GetVersion().GetValue(); version != 0 {
+			return nil, status.Errorf(codes.NotFound, "Could not find version %v of model %v", version, spec.GetName())
+		}
+		return nil, status.Errorf(codes.NotFound, "Could not find any versions of model %v", spec.GetName())
+	}
+	if model.Err != nil {
+		return nil, model.Err
+	}
+	return model.status(spec, elapsed)
+}
+
+// Build the status response of the model at the elapsed time
+func (m Model) status(spec *tfproto.ModelSpec, elapsed time.Duration) (*tfproto.GetModelStatusResponse, error) {
+	version := spec.GetVersion().GetValue()
+	if label := spec.GetVersionLabel(); label != "" {
+		var ok bool
+		version, ok = m.Labels[label]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "Unrecognized servable version label: %v", label)
+		}
+	}
+
+	response := &tfproto.GetModelStatusResponse{}
+	for _, v := range m.Versions {
+		step, ok := v.stepAt(elapsed)
+		if !ok || (version != 0 && v.Version != version) {
+			continue
+		}
+		response.ModelVersionStatus = append(response.ModelVersionStatus, &tfproto.ModelVersionStatus{
+			Version: v.Version,
+			State:   step.State,
+			Status:  &tfproto.StatusProto{ErrorCode: step.ErrorCode, ErrorMessage: step.ErrorMessage},
+		})
+	}
+	if len(response.ModelVersionStatus) == 0 {
+		if version != 0 {
+			return nil, status.Errorf(codes.NotFound, "Could not find version %v of model %v", version, m.Name)
+		}
+		return nil, status.Errorf(codes.NotFound, "Could not find any versions of model %v", m.Name)
+	}
+	return response, nil
+}
+
+// Find the latest step of the timeline at the elapsed time
+func (v Version) stepAt(elapsed time.Duration) (Step, bool) {
+	var current Step
+	found := false
+	for _, step := range v.Timeline {
+		if step.After <= elapsed {
+			current = step
+			found = true
+		}
+	}
+	return current, found
+}
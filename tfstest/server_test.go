@@ -0,0 +1,101 @@
+//
+// Copyright 2020 Cody Collier <cody@telnet.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package tfstest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/codycollier/tfs-model-status-probe/probe"
+	"github.com/codycollier/tfs-model-status-probe/tfproto/tfproto"
+)
+
+// Check a model against the server over bufconn
+func check(t *testing.T, server *Server, model probe.Model, rpcTimeout time.Duration) *probe.Result {
+	p, err := probe.New(probe.Config{
+		Addr:       server.Addr,
+		Dialer:     server.Dialer(),
+		Models:     []probe.Model{model},
+		RPCTimeout: rpcTimeout,
+	})
+	assert.Nil(t, err)
+	defer p.Close()
+	return p.Check(context.Background())
+}
+
+func TestServer(t *testing.T) {
+	server := NewBufconnServer(
+		Model{
+			Name: "half_plus_two",
+			Versions: []Version{
+				{Version: 1, Timeline: Available()},
+				{Version: 2, Timeline: []Step{{State: tfproto.ModelVersionStatus_LOADING}}},
+				{Version: 3, Timeline: []Step{{After: time.Hour, State: tfproto.ModelVersionStatus_LOADING}}},
+			},
+			Labels: map[string]int64{"stable": 1},
+		},
+		Model{Name: "resnet", Versions: []Version{{Version: 1, Timeline: []Step{{State: tfproto.ModelVersionStatus_LOADING}}}}},
+		Model{Name: "broken", Versions: []Version{{Version: 1, Timeline: []Step{
+			{State: tfproto.ModelVersionStatus_END, ErrorCode: tfproto.Code_NOT_FOUND, ErrorMessage: "no servable"},
+		}}}},
+		Model{Name: "unavailable", Err: status.Error(codes.Unavailable, "overloaded")},
+		Model{Name: "hang", Hang: true},
+	)
+	defer server.Close()
+
+	expected := map[probe.Model]int{
+		{Name: "half_plus_two"}:                  0,
+		{Name: "half_plus_two", Version: 2}:      32,
+		{Name: "resnet"}:                         32,
+		{Name: "half_plus_two", Version: 1}:      0,
+		{Name: "half_plus_two", Label: "stable"}: 0,
+		{Name: "half_plus_two", Label: "canary"}: 13,
+		{Name: "half_plus_two", Version: 3}:      12,
+		{Name: "missing"}:                        10,
+		{Name: "missing", Version: 5}:            10,
+		{Name: "broken"}:                         65,
+		{Name: "unavailable"}:                    3,
+		{Name: "hang"}:                           3,
+	}
+	for model, code := range expected {
+		result := check(t, server, model, 100*time.Millisecond)
+		assert.Equal(t, code, result.ExitCode, model.String())
+	}
+	// a version not found is followed by a call for the whole model
+	assert.Equal(t, len(expected)+2, server.Calls())
+}
+
+func TestServerTimeline(t *testing.T) {
+	server := NewServer(Model{Name: "half_plus_two", Versions: []Version{{Version: 1, Timeline: []Step{
+		{State: tfproto.ModelVersionStatus_LOADING},
+		{After: 200 * time.Millisecond, State: tfproto.ModelVersionStatus_AVAILABLE},
+	}}}})
+	defer server.Close()
+
+	model := probe.Model{Name: "half_plus_two"}
+	assert.Equal(t, 32, check(t, server, model, time.Second).ExitCode)
+	time.Sleep(200 * time.Millisecond)
+	assert.Equal(t, 0, check(t, server, model, time.Second).ExitCode)
+
+	server.RemoveModel("half_plus_two")
+	assert.Equal(t, 10, check(t, server, model, time.Second).ExitCode)
+}
@@ -0,0 +1,379 @@
+//
+// Copyright 2020 Cody Collier <cody@telnet.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/codycollier/tfs-model-status-probe/probe"
+	"github.com/codycollier/tfs-model-status-probe/tfproto/tfproto"
+)
+
+// The states whose time is accounted for, on the way in and out of serving
+var transientStates = []tfproto.ModelVersionStatus_State{
+	tfproto.ModelVersionStatus_START,
+	tfproto.ModelVersionStatus_LOADING,
+	tfproto.ModelVersionStatus_UNLOADING,
+}
+
+// watchEvent is a change of a model version status, or of the model as a
+// whole when it is not found or can not be reached (no version)
+type watchEvent struct {
+	Time          time.Time          `json:"time"`
+	Model         string             `json:"model"`
+	Version       int64              `json:"version,omitempty"`
+	State         string             `json:"state"`
+	Previous      string             `json:"previous,omitempty"`
+	PreviousSecs  float64            `json:"previous_seconds,omitempty"`
+	ErrorCode     string             `json:"error_code,omitempty"`
+	ErrorMessage  string             `json:"error_message,omitempty"`
+	TransientSecs map[string]float64 `json:"transient_seconds,omitempty"`
+}
+
+func (e watchEvent) String() string {
+	name := e.Model
+	if e.Version != 0 {
+		name = fmt.Sprintf("%v version %v", e.Model, e.Version)
+	}
+	msg := fmt.Sprintf("%v: %v", name, e.State)
+	if e.Previous != "" {
+		msg = fmt.Sprintf("%v: %v -> %v after %.1fs", name, e.Previous, e.State, e.PreviousSecs)
+	}
+	if e.ErrorCode != "" || e.ErrorMessage != "" {
+		msg += fmt.Sprintf(" (%v)", strings.TrimPrefix(e.ErrorCode+": "+e.ErrorMessage, ": "))
+	}
+	var spent []string
+	for _, state := range transientStates {
+		if secs, ok := e.TransientSecs[state.String()]; ok {
+			spent = append(spent, fmt.Sprintf("%v %.1fs", state, secs))
+		}
+	}
+	if len(spent) > 0 {
+		msg += ", spent " + strings.Join(spent, ", ")
+	}
+	return msg
+}
+
+type versionKey struct {
+	model   string
+	version int64
+}
+
+// versionWatch is the last seen status of a version, since when, and the
+// time spent in each transient state it has left
+type versionWatch struct {
+	state        tfproto.ModelVersionStatus_State
+	errorCode    tfproto.Code
+	errorMessage string
+	since        time.Time
+	spent        map[tfproto.ModelVersionStatus_State]time.Duration
+}
+
+// watcher compares each poll against the last, producing the changes
+type watcher struct {
+	versions map[versionKey]*versionWatch
+	models   map[string]string
+}
+
+func newWatcher() *watcher {
+	return &watcher{versions: make(map[versionKey]*versionWatch), models: make(map[string]string)}
+}
+
+// The condition of a model as a whole, empty when its status was returned
+func modelCondition(r probe.ModelResult) string {
+	switch {
+	case r.Response != nil:
+		return ""
+	case r.ExitCode == probe.ExitModelNotFound:
+		return "NOT_FOUND"
+	default:
+		return "ERROR"
+	}
+}
+
+// Record a poll, returning the changes in model order
+func (w *watcher) update(now time.Time, models []probe.Model, result *probe.Result) []watchEvent {
+	var events []watchEvent
+	for i, model := range models {
+		name := model.String()
+
+		// without a connection, nothing is known of the versions
+		r := probe.ModelResult{Model: model, Err: result.Err, ExitCode: result.ExitCode}
+		if i < len(result.Models) {
+			r = result.Models[i]
+		}
+		condition := modelCondition(r)
+		if condition != w.models[name] && condition != "" {
+			event := watchEvent{Time: now, Model: name, State: condition}
+			if r.Err != nil {
+				event.ErrorMessage = r.Err.Error()
+			}
+			events = append(events, event)
+		}
+		w.models[name] = condition
+		if condition == "ERROR" {
+			continue
+		}
+
+		// version changes, with versions no longer reported gone
+		seen := make(map[int64]bool)
+		for _, res := range r.Response.GetModelVersionStatus() {
+			seen[res.Version] = true
+			if event, changed := w.updateVersion(now, name, res); changed {
+				events = append(events, event)
+			}
+		}
+		var gone []int64
+		for key := range w.versions {
+			if key.model == name && !seen[key.version] {
+				gone = append(gone, key.version)
+			}
+		}
+		sort.Slice(gone, func(i, j int) bool { return gone[i] < gone[j] })
+		for _, version := range gone {
+			key := versionKey{name, version}
+			last := w.versions[key]
+			events = append(events, watchEvent{
+				Time:         now,
+				Model:        name,
+				Version:      version,
+				State:        "GONE",
+				Previous:     last.state.String(),
+				PreviousSecs: now.Sub(last.since).Seconds(),
+			})
+			delete(w.versions, key)
+		}
+	}
+	return events
+}
+
+// Record the status of a version, returning the change if any
+func (w *watcher) updateVersion(now time.Time, model string, res *tfproto.ModelVersionStatus) (watchEvent, bool) {
+	key := versionKey{model, res.Version}
+	errorCode := res.GetStatus().GetErrorCode()
+	errorMessage := res.GetStatus().GetErrorMessage()
+	event := watchEvent{Time: now, Model: model, Version: res.Version, State: res.State.String()}
+	if errorCode != tfproto.Code_OK || errorMessage != "" {
+		event.ErrorCode = errorCode.String()
+		event.ErrorMessage = errorMessage
+	}
+
+	last, ok := w.versions[key]
+	if !ok {
+		w.versions[key] = &versionWatch{
+			state:        res.State,
+			errorCode:    errorCode,
+			errorMessage: errorMessage,
+			since:        now,
+			spent:        make(map[tfproto.ModelVersionStatus_State]time.Duration),
+		}
+		return event, true
+	}
+	if last.state == res.State && last.errorCode == errorCode && last.errorMessage == errorMessage {
+		return watchEvent{}, false
+	}
+
+	if last.state != res.State {
+		elapsed := now.Sub(last.since)
+		last.spent[last.state] += elapsed
+		event.Previous = last.state.String()
+		event.PreviousSecs = elapsed.Seconds()
+		last.state = res.State
+		last.since = now
+	}
+	last.errorCode = errorCode
+	last.errorMessage = errorMessage
+	for _, state := range transientStates {
+		if spent, ok := last.spent[state]; ok {
+			if event.TransientSecs == nil {
+				event.TransientSecs = make(map[string]float64)
+			}
+			event.TransientSecs[state.String()] = spent.Seconds()
+		}
+	}
+	return event, true
+}
+
+// untilCondition is the terminal condition of a watch: the check passing
+// ("available"), every model gone ("gone"), or a version reaching a state
+// ("N" or "N:STATE", AVAILABLE by default)
+type untilCondition struct {
+	kind    string
+	version int64
+	state   tfproto.ModelVersionStatus_State
+}
+
+func parseUntil(value string) (untilCondition, error) {
+	switch value {
+	case "", "available", "gone":
+		return untilCondition{kind: value}, nil
+	}
+	u := untilCondition{kind: "version", state: tfproto.ModelVersionStatus_AVAILABLE}
+	version := value
+	if i := strings.Index(value, ":"); i >= 0 {
+		state, ok := tfproto.ModelVersionStatus_State_value[strings.ToUpper(value[i+1:])]
+		if !ok {
+			return u, fmt.Errorf("unknown state in -until: %v", value)
+		}
+		version, u.state = value[:i], tfproto.ModelVersionStatus_State(state)
+	}
+	var err error
+	u.version, err = strconv.ParseInt(version, 10, 64)
+	if err != nil || u.version <= 0 {
+		return u, fmt.Errorf("invalid -until: %v (available, gone, or a version with an optional :STATE)", value)
+	}
+	return u, nil
+}
+
+// Report whether the condition is met by a poll, with the exit code to
+// exit with. Waiting on a version stops when it ends in another state.
+func (u untilCondition) met(result *probe.Result) (bool, int) {
+	switch u.kind {
+	case "available":
+		return result.ExitCode == probe.ExitAvailable, probe.ExitAvailable
+	case "gone":
+		if len(result.Models) == 0 {
+			return false, 0
+		}
+		for _, r := range result.Models {
+			if r.ExitCode != probe.ExitModelNotFound {
+				return false, 0
+			}
+		}
+		return true, probe.ExitAvailable
+	case "version":
+		if len(result.Models) == 0 {
+			return false, 0
+		}
+		for _, r := range result.Models {
+			var found *tfproto.ModelVersionStatus
+			for _, res := range r.Response.GetModelVersionStatus() {
+				if res.Version == u.version {
+					found = res
+				}
+			}
+			if found != nil && found.State == tfproto.ModelVersionStatus_END && u.state != tfproto.ModelVersionStatus_END {
+				if code := found.GetStatus().GetErrorCode(); code != tfproto.Code_OK {
+					return true, probe.LoadFailedExitCode(code)
+				}
+				return true, probe.ExitStateEnd
+			}
+			if found == nil || found.State != u.state {
+				return false, 0
+			}
+		}
+		return true, probe.ExitAvailable
+	}
+	return false, 0
+}
+
+// Write watch events as text lines or json lines
+func writeWatchEvents(w io.Writer, events []watchEvent, jsonLines bool) {
+	for _, event := range events {
+		if jsonLines {
+			json.NewEncoder(w).Encode(event)
+		} else {
+			fmt.Fprintf(w, "%v %v\n", event.Time.Format("2006/01/02 15:04:05"), event)
+		}
+	}
+}
+
+// Run the watch subcommand until the terminal condition, the timeout or an
+// interrupt, returning the mapped exit code
+func runWatch(args []string) int {
+	fs := subcommandFlags("watch")
+	interval := fs.Duration("check-interval", time.Second, "Interval between model status polls")
+	until := fs.String("until", "", "Exit once: available (the check passes), gone (every model not found), or a version reaches a state, as N or N:STATE (default AVAILABLE)")
+	timeout := fs.Duration("timeout", 0, "Give up after this long, with exit code 14 (default no timeout)")
+	fs.Parse(args)
+
+	exitCodes, err := newExitCodeMap(*flExitCodeProfile, *flExitCodeFile, *flExitCodeMap)
+	if err != nil {
+		log.Printf("Error: %v\n", err)
+		return probe.ExitInvalidConfig
+	}
+	condition, err := parseUntil(*until)
+	var config probe.Config
+	if err == nil {
+		config, err = configFromFlags()
+	}
+	if err == nil && config.Wait {
+		err = errors.New("the -wait option is not supported by watch")
+	}
+	if err == nil && *interval <= 0 {
+		err = errors.New("the -check-interval must be positive")
+	}
+	if err == nil && *flOutput == "nagios" {
+		err = errors.New("the nagios output is not supported by watch")
+	}
+	var p *probe.Prober
+	if err == nil {
+		config.Logger = nil
+		p, err = probe.New(config)
+	}
+	if err != nil {
+		log.Printf("Error: %v\n", err)
+		return exitCodes.exitCode(probe.ExitInvalidConfig)
+	}
+	defer p.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if *timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+
+	models := p.Config().Models
+	w := newWatcher()
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+	retval := probe.ExitAvailable
+	for {
+		result := p.CheckModels(ctx, models)
+		if ctx.Err() != nil {
+			return exitCodes.exitCode(probe.ExitWaitDeadline)
+		}
+		writeWatchEvents(os.Stdout, w.update(time.Now(), models, result), *flOutput == "json")
+		retval = result.ExitCode
+		if done, code := condition.met(result); done {
+			return exitCodes.exitCode(code)
+		}
+
+		select {
+		case <-ctx.Done():
+			return exitCodes.exitCode(probe.ExitWaitDeadline)
+		case <-signals:
+			return exitCodes.exitCode(retval)
+		case <-ticker.C:
+		}
+	}
+}
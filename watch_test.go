@@ -0,0 +1,140 @@
+//
+// Copyright 2020 Cody Collier <cody@telnet.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/codycollier/tfs-model-status-probe/probe"
+	"github.com/codycollier/tfs-model-status-probe/tfproto/tfproto"
+	"github.com/codycollier/tfs-model-status-probe/tfstest"
+)
+
+// A poll result with the versions of one model in the states
+func watchResult(model probe.Model, states map[int64]tfproto.ModelVersionStatus_State) *probe.Result {
+	response := &tfproto.GetModelStatusResponse{}
+	for version := int64(1); version <= 3; version++ {
+		if state, ok := states[version]; ok {
+			response.ModelVersionStatus = append(response.ModelVersionStatus, &tfproto.ModelVersionStatus{Version: version, State: state})
+		}
+	}
+	return &probe.Result{Models: []probe.ModelResult{{Model: model, Response: response}}}
+}
+
+func TestWatcher(t *testing.T) {
+	model := probe.Model{Name: "half_plus_two"}
+	models := []probe.Model{model}
+	w := newWatcher()
+	start := time.Date(2020, 11, 20, 17, 0, 0, 0, time.UTC)
+	at := func(secs int) time.Time { return start.Add(time.Duration(secs) * time.Second) }
+
+	events := w.update(at(0), models, watchResult(model, map[int64]tfproto.ModelVersionStatus_State{1: tfproto.ModelVersionStatus_AVAILABLE, 2: tfproto.ModelVersionStatus_START}))
+	assert.Len(t, events, 2)
+	assert.Equal(t, "half_plus_two version 2: START", events[1].String())
+
+	// no change, no events
+	assert.Empty(t, w.update(at(1), models, watchResult(model, map[int64]tfproto.ModelVersionStatus_State{1: tfproto.ModelVersionStatus_AVAILABLE, 2: tfproto.ModelVersionStatus_START})))
+
+	events = w.update(at(2), models, watchResult(model, map[int64]tfproto.ModelVersionStatus_State{1: tfproto.ModelVersionStatus_AVAILABLE, 2: tfproto.ModelVersionStatus_LOADING}))
+	assert.Len(t, events, 1)
+	events = w.update(at(12), models, watchResult(model, map[int64]tfproto.ModelVersionStatus_State{1: tfproto.ModelVersionStatus_UNLOADING, 2: tfproto.ModelVersionStatus_AVAILABLE}))
+	assert.Len(t, events, 2)
+	assert.Equal(t, "half_plus_two version 1: AVAILABLE -> UNLOADING after 12.0s", events[0].String())
+	assert.Equal(t, "half_plus_two version 2: LOADING -> AVAILABLE after 10.0s, spent START 2.0s, LOADING 10.0s", events[1].String())
+
+	// a version no longer reported is gone
+	events = w.update(at(13), models, watchResult(model, map[int64]tfproto.ModelVersionStatus_State{2: tfproto.ModelVersionStatus_AVAILABLE}))
+	assert.Len(t, events, 1)
+	assert.Equal(t, "half_plus_two version 1: UNLOADING -> GONE after 1.0s", events[0].String())
+
+	// the whole model gone, once
+	notFound := &probe.Result{Models: []probe.ModelResult{{Model: model, ExitCode: probe.ExitModelNotFound, Err: errors.New("not found")}}}
+	events = w.update(at(14), models, notFound)
+	assert.Len(t, events, 2)
+	assert.Equal(t, "NOT_FOUND", events[0].State)
+	assert.Equal(t, "GONE", events[1].State)
+	assert.Empty(t, w.update(at(15), models, notFound))
+
+	// a failed connection is reported, without versions going
+	w.update(at(16), models, watchResult(model, map[int64]tfproto.ModelVersionStatus_State{2: tfproto.ModelVersionStatus_AVAILABLE}))
+	events = w.update(at(17), models, &probe.Result{ExitCode: 2, Err: errors.New("connection refused")})
+	assert.Len(t, events, 1)
+	assert.Equal(t, "half_plus_two: ERROR (connection refused)", events[0].String())
+}
+
+func TestParseUntil(t *testing.T) {
+	for value, expected := range map[string]untilCondition{
+		"":          {},
+		"available": {kind: "available"},
+		"gone":      {kind: "gone"},
+		"2":         {kind: "version", version: 2, state: tfproto.ModelVersionStatus_AVAILABLE},
+		"2:end":     {kind: "version", version: 2, state: tfproto.ModelVersionStatus_END},
+	} {
+		u, err := parseUntil(value)
+		assert.Nil(t, err, value)
+		assert.Equal(t, expected, u, value)
+	}
+	for _, value := range []string{"0", "two", "2:READY"} {
+		_, err := parseUntil(value)
+		assert.NotNil(t, err, value)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	server := tfstest.NewServer(tfstest.Model{Name: "half_plus_two", Versions: []tfstest.Version{
+		{Version: 1, Timeline: tfstest.Available()},
+		{Version: 2, Timeline: []tfstest.Step{
+			{State: tfproto.ModelVersionStatus_LOADING},
+			{After: 300 * time.Millisecond, State: tfproto.ModelVersionStatus_AVAILABLE},
+		}},
+		{Version: 3, Timeline: []tfstest.Step{
+			{State: tfproto.ModelVersionStatus_LOADING},
+			{After: 100 * time.Millisecond, State: tfproto.ModelVersionStatus_END, ErrorCode: tfproto.Code_NOT_FOUND},
+		}},
+	}})
+	defer server.Close()
+
+	out, code := runMainOutput(t, "watch", "-addr="+server.Addr, "-model-name=half_plus_two", "-check-interval=50ms", "-until=2", "-output=json")
+	assert.Equal(t, 0, code)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	var last watchEvent
+	assert.Nil(t, json.Unmarshal([]byte(lines[len(lines)-1]), &last))
+	assert.Equal(t, int64(2), last.Version)
+	assert.Equal(t, "AVAILABLE", last.State)
+	assert.Equal(t, "LOADING", last.Previous)
+	assert.Contains(t, last.TransientSecs, "LOADING")
+	assert.Contains(t, out, `"state":"END"`)
+
+	// a failed load ends the wait on it
+	assert.Equal(t, 65, runMain(t, "watch", "-addr="+server.Addr, "-model-name=half_plus_two", "-check-interval=50ms", "-until=3"))
+	assert.Equal(t, 14, runMain(t, "watch", "-addr="+server.Addr, "-model-name=half_plus_two", "-check-interval=50ms", "-until=gone", "-timeout=200ms"))
+	assert.Equal(t, 0, runMain(t, "watch", "-addr="+server.Addr, "-model-name=resnet", "-until=gone"))
+	assert.Equal(t, 1, runMain(t, "watch", "-addr="+server.Addr, "-model-name=half_plus_two", "-until=soon"))
+	assert.Equal(t, 1, runMain(t, "watch", "-addr="+server.Addr, "-model-name=half_plus_two", "-check-interval=0"))
+	assert.Equal(t, 3, runMain(t, "watch", "-addr="+server.Addr, "-model-name=half_plus_two", "-check-interval=-1s", "-exit-code-profile=nagios"))
+
+	// the exit codes are mapped
+	assert.Equal(t, 2, runMain(t, "watch", "-addr="+server.Addr, "-model-name=half_plus_two", "-check-interval=50ms", "-until=3", "-exit-code-profile=nagios"))
+	assert.Equal(t, 0, runMain(t, "watch", "-addr="+server.Addr, "-model-name=half_plus_two", "-check-interval=50ms", "-until=gone", "-timeout=100ms", "-exit-code-map=wait_deadline=0"))
+	assert.Equal(t, 1, runMain(t, "watch", "-exit-code-map=nonsense=0"))
+}